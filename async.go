@@ -0,0 +1,168 @@
+package behave
+
+import (
+	"context"
+	"strings"
+)
+
+// AsyncAction is a leaf node that performs its action on a separate goroutine so that slow work does not
+// block the tree. The first tick starts Run on a new goroutine and returns Running; subsequent ticks return
+// Running until Run completes, and then return its result. Run should return Success or Failure; any other
+// status is treated as Failure, as is a panic inside Run.
+//
+// The context passed to Run carries the values of the context of the tick that started the work, but not its
+// cancellation, so that the work outlives a tick whose context is cancelled once it returns. It is cancelled
+// when the node is reset or halted, or when the context of a later tick is done.
+type AsyncAction struct {
+	Name   string // Optional name that identifies the action in tree definitions, String(), logs and tooling
+	Run    func(ctx context.Context) Status
+	status Status
+	cancel context.CancelFunc // Cancels the in-flight work, or nil if no work is in flight
+	done   chan Status        // Receives the result of the in-flight work
+	path   string             // Path of the node in its tree, assigned by AssignPaths
+}
+
+// Tick executes the AsyncAction node with a background context.
+//
+// Returns:
+//   - The current status of the AsyncAction node after execution. See TickContext for details.
+func (a *AsyncAction) Tick() Status {
+	return a.TickContext(context.Background())
+}
+
+// TickContext starts the action's Run function on a new goroutine if it is not already in flight, and
+// otherwise checks whether it has completed. This method never blocks waiting for Run. While a Replay is in
+// progress, the action returns its recorded result instead of running.
+//
+// Parameters:
+//   - ctx: The context for this tick. The work started by the first tick runs with a context that carries its
+//     values. If ctx is cancelled, any in-flight work is cancelled and the action fails.
+//
+// Returns:
+//   - The current status of the AsyncAction node after execution. Running while the work is in flight, and the
+//     result of Run once it has completed. If the Run function is nil, it returns Failure.
+func (a *AsyncAction) TickContext(ctx context.Context) Status {
+	if ctx.Err() != nil {
+		a.stop()
+		a.status = Failure
+		return a.status
+	}
+	if status, ok := replayResult(ctx, a); ok {
+		a.stop()
+		a.status = status
+		return a.status
+	}
+
+	if a.Run == nil {
+		a.status = Failure
+		return a.status
+	}
+
+	// Start the work on the first tick
+	if a.done == nil {
+		a.start(ctx)
+		a.status = Running
+		return a.status
+	}
+
+	select {
+	case status := <-a.done:
+		a.stop()
+		switch status {
+		case Success, Failure:
+			a.status = status
+		default:
+			a.status = Failure
+		}
+	default:
+		a.status = Running
+	}
+	return a.status
+}
+
+// start launches the action's Run function on a new goroutine.
+func (a *AsyncAction) start(ctx context.Context) {
+	workCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	done := make(chan Status, 1)
+	a.cancel = cancel
+	a.done = done
+
+	go func() {
+		status := Failure
+		defer func() {
+			// A panic in Run is reported as a Failure rather than crashing the program
+			recover()
+			done <- status
+		}()
+		status = a.Run(workCtx)
+	}()
+}
+
+// stop cancels the in-flight work, if any, and forgets about it.
+func (a *AsyncAction) stop() {
+	if a.cancel != nil {
+		a.cancel()
+	}
+	a.cancel = nil
+	a.done = nil
+}
+
+// Reset cancels any in-flight work and resets the AsyncAction node to its initial state.
+//
+// Returns:
+//   - The status of the AsyncAction node after reset, which will be Ready.
+func (a *AsyncAction) Reset() Status {
+	a.stop()
+	a.status = Ready
+	return a.status
+}
+
+// Halt cancels any in-flight work and returns the AsyncAction node to the Ready state.
+//
+// Returns:
+//   - The status of the AsyncAction node after halting, which will be Ready.
+func (a *AsyncAction) Halt() Status {
+	return a.Reset()
+}
+
+// Status returns the current status of the AsyncAction node.
+//
+// Returns:
+//   - The current status of the AsyncAction node, which can be Ready, Running, Success, or Failure.
+func (a *AsyncAction) Status() Status {
+	return a.status
+}
+
+// String returns a string representation of the AsyncAction node.
+//
+// Returns:
+//   - A string that represents the AsyncAction node, including its current status. The format is "AsyncAction (Status)", with the name and path of the node after its type when they are set.
+func (a *AsyncAction) String() string {
+	var builder strings.Builder
+	builder.WriteString(nodeLabel("AsyncAction", a.Name, a.path) + " (" + a.Status().String() + ")")
+	return builder.String()
+}
+
+// NodeName returns the optional name of the AsyncAction node.
+//
+// Returns:
+//   - The Name of the node, or the empty string if it has none.
+func (a *AsyncAction) NodeName() string {
+	return a.Name
+}
+
+// Path returns the path of the AsyncAction node in its tree, such as "root/0/2".
+//
+// Returns:
+//   - The path assigned by AssignPaths, or the empty string if none has been assigned.
+func (a *AsyncAction) Path() string {
+	return a.path
+}
+
+// SetPath sets the path of the AsyncAction node in its tree. It is called by AssignPaths.
+//
+// Parameters:
+//   - path: The path of the node.
+func (a *AsyncAction) SetPath(path string) {
+	a.path = path
+}
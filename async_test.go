@@ -0,0 +1,187 @@
+package behave
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// waitForStatus ticks the node until it returns a status other than Running or the deadline passes.
+func waitForStatus(t *testing.T, node Node) Status {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if status := node.Tick(); status != Running {
+			return status
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("%s still Running after 1s", node.String())
+	return Running
+}
+
+func TestAsyncAction_Tick(t *testing.T) {
+	release := make(chan struct{})
+	action := &AsyncAction{Run: func(ctx context.Context) Status {
+		<-release
+		return Success
+	}}
+
+	if status := action.Tick(); status != Running {
+		t.Errorf("AsyncAction.Tick() = %v, want %v", status, Running)
+	}
+	if status := action.Tick(); status != Running {
+		t.Errorf("AsyncAction.Tick() while work in flight = %v, want %v", status, Running)
+	}
+
+	close(release)
+	if status := waitForStatus(t, action); status != Success {
+		t.Errorf("AsyncAction.Tick() after work completed = %v, want %v", status, Success)
+	}
+	if action.Status() != Success {
+		t.Errorf("AsyncAction.Status() = %v, want %v", action.Status(), Success)
+	}
+}
+
+func TestAsyncAction_Results(t *testing.T) {
+	tests := []struct {
+		name     string
+		run      func(ctx context.Context) Status
+		expected Status
+	}{
+		{name: "no run func", run: nil, expected: Failure},
+		{name: "failure", run: func(ctx context.Context) Status { return Failure }, expected: Failure},
+		{name: "invalid status", run: func(ctx context.Context) Status { return Running }, expected: Failure},
+		{name: "panic", run: func(ctx context.Context) Status { panic("boom") }, expected: Failure},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			action := &AsyncAction{Run: test.run}
+			if status := waitForStatus(t, action); status != test.expected {
+				t.Errorf("AsyncAction.Tick() = %v, want %v", status, test.expected)
+			}
+		})
+	}
+}
+
+func TestAsyncAction_ResetCancelsWork(t *testing.T) {
+	cancelled := make(chan struct{})
+	action := &AsyncAction{Run: func(ctx context.Context) Status {
+		<-ctx.Done()
+		close(cancelled)
+		return Failure
+	}}
+
+	action.Tick()
+	if status := action.Reset(); status != Ready {
+		t.Errorf("AsyncAction.Reset() = %v, want %v", status, Ready)
+	}
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatalf("AsyncAction.Reset() did not cancel the in-flight work")
+	}
+}
+
+func TestAsyncAction_HaltedBySelector(t *testing.T) {
+	cancelled := make(chan struct{})
+	preempt := false
+	selector := &Selector{Children: []Node{
+		&Condition{Check: func() bool { return preempt }},
+		&AsyncAction{Run: func(ctx context.Context) Status {
+			<-ctx.Done()
+			close(cancelled)
+			return Failure
+		}},
+	}}
+
+	if status := selector.Tick(); status != Running {
+		t.Errorf("Selector.Tick() = %v, want %v", status, Running)
+	}
+	preempt = true
+	if status := selector.Tick(); status != Success {
+		t.Errorf("Selector.Tick() = %v, want %v", status, Success)
+	}
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatalf("preempted AsyncAction was not cancelled")
+	}
+}
+
+func TestAsyncAction_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	stopped := make(chan struct{})
+	action := &AsyncAction{Run: func(ctx context.Context) Status {
+		close(started)
+		<-ctx.Done()
+		close(stopped)
+		return Success
+	}}
+
+	if status := action.TickContext(ctx); status != Running {
+		t.Errorf("AsyncAction.TickContext() = %v, want %v", status, Running)
+	}
+	<-started
+	cancel()
+	if status := action.TickContext(ctx); status != Failure {
+		t.Errorf("AsyncAction.TickContext() after cancel = %v, want %v", status, Failure)
+	}
+	<-stopped
+}
+
+func TestAsyncAction_OutlivesTickContext(t *testing.T) {
+	type key struct{}
+	release := make(chan struct{})
+	action := &AsyncAction{Run: func(ctx context.Context) Status {
+		select {
+		case <-release:
+		case <-ctx.Done():
+			return Failure
+		}
+		if ctx.Value(key{}) != "value" {
+			return Failure
+		}
+		return Success
+	}}
+
+	// Each tick gets its own context, which is cancelled once the tick returns
+	tick := func() Status {
+		ctx, cancel := context.WithCancel(context.WithValue(context.Background(), key{}, "value"))
+		defer cancel()
+		return action.TickContext(ctx)
+	}
+	if status := tick(); status != Running {
+		t.Fatalf("AsyncAction.TickContext() = %v, want %v", status, Running)
+	}
+	close(release)
+	deadline := time.Now().Add(time.Second)
+	status := tick()
+	for status == Running && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+		status = tick()
+	}
+	if status != Success {
+		t.Errorf("AsyncAction.TickContext() after the work completed = %v, want %v", status, Success)
+	}
+}
+
+func TestAsyncAction_RestartsAfterCompletion(t *testing.T) {
+	runs := 0
+	action := &AsyncAction{Run: func(ctx context.Context) Status {
+		runs++
+		return Success
+	}}
+
+	waitForStatus(t, action)
+	action.Reset()
+	waitForStatus(t, action)
+	if runs != 2 {
+		t.Errorf("AsyncAction ran %d times, want 2", runs)
+	}
+	if str := action.String(); str != "AsyncAction (Success)" {
+		t.Errorf("AsyncAction.String() = %q, want %q", str, "AsyncAction (Success)")
+	}
+}
@@ -0,0 +1,103 @@
+package behave
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// maxDuration is the longest delay a Backoff returns. Longer delays are clamped to it rather than overflowing.
+const maxDuration = time.Duration(math.MaxInt64)
+
+// Backoff determines how long the Retry node waits before retrying its child after a failed attempt.
+type Backoff interface {
+	// Delay returns the time to wait before the next attempt, given the number of attempts that have failed so far.
+	Delay(attempt int) time.Duration
+}
+
+// FixedBackoff waits the same Interval before every retry.
+type FixedBackoff struct {
+	Interval time.Duration
+}
+
+// Delay returns the fixed interval.
+//
+// Parameters:
+//   - attempt: The number of attempts that have failed so far. It is ignored.
+//
+// Returns:
+//   - The configured Interval.
+func (fb FixedBackoff) Delay(attempt int) time.Duration {
+	return fb.Interval
+}
+
+// ExponentialBackoff waits Initial before the first retry and multiplies the delay by Multiplier for every
+// subsequent retry, up to Max.
+type ExponentialBackoff struct {
+	Initial    time.Duration // Delay before the first retry
+	Max        time.Duration // Upper bound for the delay. If zero, the delay is only bounded by the longest time.Duration
+	Multiplier float64       // Factor applied to the delay after each failed attempt. If less than 1, 2 is used
+}
+
+// Delay returns the exponentially increasing delay for the given attempt.
+//
+// Parameters:
+//   - attempt: The number of attempts that have failed so far, starting at 1.
+//
+// Returns:
+//   - Initial * Multiplier^(attempt-1), capped at Max, or at the longest time.Duration if Max is zero.
+func (eb ExponentialBackoff) Delay(attempt int) time.Duration {
+	multiplier := eb.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+	limit := maxDuration
+	if eb.Max > 0 {
+		limit = eb.Max
+	}
+	delay := float64(eb.Initial)
+	for i := 1; i < attempt && delay < float64(limit); i++ {
+		delay *= multiplier
+	}
+	return clampDuration(delay, limit)
+}
+
+// JitteredBackoff randomizes the delay of another Backoff so that many trees retrying at the same time
+// don't all retry in lockstep.
+type JitteredBackoff struct {
+	Backoff Backoff // The Backoff whose delay is randomized
+	Jitter  float64 // Fraction of the delay, between 0 and 1, by which the delay may vary in either direction
+}
+
+// Delay returns the delay of the wrapped Backoff, randomly varied by up to Jitter of its value.
+//
+// Parameters:
+//   - attempt: The number of attempts that have failed so far.
+//
+// Returns:
+//   - A random delay in the range [d*(1-Jitter), d*(1+Jitter)], where d is the wrapped Backoff's delay, capped
+//     at the longest time.Duration.
+func (jb JitteredBackoff) Delay(attempt int) time.Duration {
+	if jb.Backoff == nil {
+		return 0
+	}
+	delay := jb.Backoff.Delay(attempt)
+	jitter := jb.Jitter
+	if jitter <= 0 {
+		return delay
+	}
+	if jitter > 1 {
+		jitter = 1
+	}
+	factor := 1 - jitter + rand.Float64()*2*jitter
+	return clampDuration(float64(delay)*factor, maxDuration)
+}
+
+// clampDuration converts a delay in nanoseconds to a time.Duration no longer than limit.
+func clampDuration(delay float64, limit time.Duration) time.Duration {
+	// float64(math.MaxInt64) rounds up to 2^63, which does not fit in a time.Duration
+	if delay >= float64(limit) {
+		return limit
+	}
+	return time.Duration(delay)
+}
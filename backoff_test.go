@@ -0,0 +1,65 @@
+package behave
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestFixedBackoff_Delay(t *testing.T) {
+	backoff := FixedBackoff{Interval: 50 * time.Millisecond}
+	for attempt := 1; attempt <= 3; attempt++ {
+		if delay := backoff.Delay(attempt); delay != 50*time.Millisecond {
+			t.Errorf("FixedBackoff.Delay(%d) = %v, want %v", attempt, delay, 50*time.Millisecond)
+		}
+	}
+}
+
+func TestExponentialBackoff_Delay(t *testing.T) {
+	tests := []struct {
+		name     string
+		backoff  ExponentialBackoff
+		attempt  int
+		expected time.Duration
+	}{
+		{name: "first attempt", backoff: ExponentialBackoff{Initial: 10 * time.Millisecond}, attempt: 1, expected: 10 * time.Millisecond},
+		{name: "default multiplier", backoff: ExponentialBackoff{Initial: 10 * time.Millisecond}, attempt: 3, expected: 40 * time.Millisecond},
+		{name: "custom multiplier", backoff: ExponentialBackoff{Initial: 10 * time.Millisecond, Multiplier: 3}, attempt: 3, expected: 90 * time.Millisecond},
+		{name: "capped", backoff: ExponentialBackoff{Initial: 10 * time.Millisecond, Max: 25 * time.Millisecond}, attempt: 5, expected: 25 * time.Millisecond},
+		{name: "large attempt", backoff: ExponentialBackoff{Initial: time.Second, Max: time.Minute}, attempt: 1000, expected: time.Minute},
+		{name: "large attempt unbounded", backoff: ExponentialBackoff{Initial: time.Second}, attempt: 35, expected: time.Duration(math.MaxInt64)},
+		{name: "huge attempt unbounded", backoff: ExponentialBackoff{Initial: time.Second}, attempt: math.MaxInt32, expected: time.Duration(math.MaxInt64)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if delay := test.backoff.Delay(test.attempt); delay != test.expected {
+				t.Errorf("ExponentialBackoff.Delay(%d) = %v, want %v", test.attempt, delay, test.expected)
+			}
+		})
+	}
+}
+
+func TestJitteredBackoff_Delay(t *testing.T) {
+	backoff := JitteredBackoff{Backoff: FixedBackoff{Interval: 100 * time.Millisecond}, Jitter: 0.2}
+	for i := 0; i < 100; i++ {
+		delay := backoff.Delay(1)
+		if delay < 80*time.Millisecond || delay > 120*time.Millisecond {
+			t.Fatalf("JitteredBackoff.Delay() = %v, want between 80ms and 120ms", delay)
+		}
+	}
+
+	noJitter := JitteredBackoff{Backoff: FixedBackoff{Interval: time.Second}}
+	if delay := noJitter.Delay(1); delay != time.Second {
+		t.Errorf("JitteredBackoff.Delay() without jitter = %v, want %v", delay, time.Second)
+	}
+	unbounded := JitteredBackoff{Backoff: ExponentialBackoff{Initial: time.Second}, Jitter: 0.5}
+	for i := 0; i < 100; i++ {
+		if delay := unbounded.Delay(100); delay < time.Duration(math.MaxInt64/2) {
+			t.Fatalf("JitteredBackoff.Delay() of a very long delay = %v, want it not to overflow", delay)
+		}
+	}
+	if delay := (JitteredBackoff{Jitter: 0.5}).Delay(1); delay != 0 {
+		t.Errorf("JitteredBackoff.Delay() without a Backoff = %v, want 0", delay)
+	}
+}
@@ -0,0 +1,77 @@
+// Package behaveotel traces the ticks of behave trees with OpenTelemetry. It is a separate module, so that the
+// behave package itself doesn't depend on OpenTelemetry.
+package behaveotel
+
+import (
+	"context"
+
+	"github.com/rbrabson/behave"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracer is a behave.Tracer that records the ticks of a tree as OpenTelemetry spans. The span of every tick is a
+// child of the OpenTelemetry span carried by the context passed to BehaviorTree.TickContext, if any.
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+// NewTracer creates a new Tracer.
+//
+// Parameters:
+//   - tracer: The OpenTelemetry tracer that starts the spans, such as otel.Tracer("behave").
+//
+// Returns:
+//   - A pointer to a new Tracer instance that starts its spans with the given tracer.
+func NewTracer(tracer trace.Tracer) *Tracer {
+	return &Tracer{tracer: tracer}
+}
+
+// Start starts an OpenTelemetry span that is a child of the span carried by ctx, if any.
+//
+// Parameters:
+//   - ctx: The context of the tick.
+//   - name: The name of the span.
+//
+// Returns:
+//   - A copy of ctx that carries the new span, and the span.
+func (t *Tracer) Start(ctx context.Context, name string) (context.Context, behave.Span) {
+	ctx, span := t.tracer.Start(ctx, name)
+	return ctx, Span{span: span}
+}
+
+// Span is a behave.Span that wraps an OpenTelemetry span.
+type Span struct {
+	span trace.Span
+}
+
+// SetAttribute sets a string attribute of the span.
+//
+// Parameters:
+//   - key: The key of the attribute, such as behave.AttrStatus.
+//   - value: The value of the attribute.
+func (s Span) SetAttribute(key, value string) {
+	s.span.SetAttributes(attribute.String(key, value))
+}
+
+// SetError sets the status of the span to codes.Error.
+//
+// Parameters:
+//   - description: The description of the error.
+func (s Span) SetError(description string) {
+	s.span.SetStatus(codes.Error, description)
+}
+
+// End completes the span.
+func (s Span) End() {
+	s.span.End()
+}
+
+// OTel returns the OpenTelemetry span that the Span wraps.
+//
+// Returns:
+//   - The wrapped OpenTelemetry span.
+func (s Span) OTel() trace.Span {
+	return s.span
+}
@@ -0,0 +1,77 @@
+package behaveotel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rbrabson/behave"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracer(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	defer provider.Shutdown(context.Background())
+
+	bt := behave.New(&behave.Sequence{Name: "patrol", Children: []behave.Node{
+		&behave.Condition{Check: func() bool { return true }},
+		&behave.Action{Name: "walk", Run: func() behave.Status { return behave.Failure }},
+	}})
+	bt.Tracer = NewTracer(provider.Tracer("behave"))
+
+	ctx, request := provider.Tracer("test").Start(context.Background(), "GET /patrol")
+	if status := bt.TickContext(ctx); status != behave.Failure {
+		t.Fatalf("BehaviorTree.TickContext() = %v, want %v", status, behave.Failure)
+	}
+	request.End()
+
+	spans := map[string]tracetest.SpanStub{}
+	for _, span := range exporter.GetSpans() {
+		spans[span.Name] = span
+	}
+	want := []struct {
+		name, parent string
+		status       string
+		code         codes.Code
+	}{
+		{"BehaviorTree.Tick", "GET /patrol", "Failure", codes.Error},
+		{"patrol", "BehaviorTree.Tick", "Failure", codes.Error},
+		{"Condition", "patrol", "Success", codes.Unset},
+		{"walk", "patrol", "Failure", codes.Error},
+	}
+	if len(spans) != len(want)+1 {
+		t.Fatalf("exported %d spans, want the request, the tree and its %d nodes", len(spans), len(want)-1)
+	}
+	for _, w := range want {
+		span, ok := spans[w.name]
+		if !ok {
+			t.Errorf("span %q was not exported", w.name)
+			continue
+		}
+		if parent := spans[w.parent]; span.Parent.SpanID() != parent.SpanContext.SpanID() {
+			t.Errorf("span %q is not a child of %q", w.name, w.parent)
+		}
+		if !hasAttribute(span.Attributes, attribute.String(behave.AttrStatus, w.status)) {
+			t.Errorf("span %q has attributes %v, want %s=%s", w.name, span.Attributes, behave.AttrStatus, w.status)
+		}
+		if span.Status.Code != w.code {
+			t.Errorf("span %q has status %v, want %v", w.name, span.Status.Code, w.code)
+		}
+	}
+	if walk := spans["walk"]; !hasAttribute(walk.Attributes, attribute.String(behave.AttrNodePath, "root/1")) {
+		t.Errorf("span %q has attributes %v, want its path", walk.Name, walk.Attributes)
+	}
+}
+
+// hasAttribute reports whether the attributes contain the given one.
+func hasAttribute(attributes []attribute.KeyValue, want attribute.KeyValue) bool {
+	for _, attr := range attributes {
+		if attr == want {
+			return true
+		}
+	}
+	return false
+}
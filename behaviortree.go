@@ -13,13 +13,19 @@ package behave
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
+// ErrInvalidConfig is returned, wrapped with details, when a node is configured with invalid parameters.
+var ErrInvalidConfig = errors.New("behave: invalid node configuration")
+
 // Status represents the result of a behavior tree node's execution.
 type Status int
 
@@ -54,10 +60,68 @@ type Node interface {
 	String() string // Get a string representation of the node
 }
 
+// ContextNode is implemented by nodes that can be ticked with a context. All built-in nodes implement
+// ContextNode; their Tick method is equivalent to calling TickContext with a background context.
+type ContextNode interface {
+	Node
+	TickContext(ctx context.Context) Status // Run the node on each tick using the given context
+}
+
+// TickNode ticks the node with the given context. Nodes that implement ContextNode are ticked with
+// TickContext, while all other nodes fall back to Tick. Custom composite and decorator nodes should use
+// TickNode to tick their children so the context reaches every node in the tree, and so the tick is reported
+// to the observers carried by the context (see WithObserver) and traced by its Tracer (see WithTracer).
+//
+// Parameters:
+//   - ctx: The context for this tick.
+//   - node: The node to tick.
+//
+// Returns:
+//   - The status of the node after execution.
+func TickNode(ctx context.Context, node Node) Status {
+	return observeTick(ctx, node, func() Status {
+		return traceTick(ctx, node, func(ctx context.Context) Status {
+			if cn, ok := node.(ContextNode); ok {
+				return cn.TickContext(ctx)
+			}
+			return node.Tick()
+		})
+	})
+}
+
+// Halter is implemented by nodes that can be interrupted while Running. Unlike Reset, which restarts a node,
+// Halt tells a node that its work has been abandoned so it can cancel in-flight operations and release
+// resources. Built-in composite nodes halt a Running child when they switch away from it.
+type Halter interface {
+	Halt() Status // Interrupt the node and return it to the Ready state
+}
+
+// HaltNode halts the node. Nodes that implement Halter are halted with Halt, while all other nodes fall back
+// to Reset. Custom composite and decorator nodes should use HaltNode to interrupt preempted children.
+//
+// Parameters:
+//   - node: The node to halt.
+//
+// Returns:
+//   - The status of the node after halting, which will normally be Ready.
+func HaltNode(node Node) Status {
+	if h, ok := node.(Halter); ok {
+		return h.Halt()
+	}
+	return node.Reset()
+}
+
 // BehaviorTree represents a behavior tree with a root node.
 type BehaviorTree struct {
-	Root   Node
-	status Status
+	Root            Node
+	Blackboard      *Blackboard // Data shared by the nodes of the tree. If nil, an empty Blackboard is created on the first Tick.
+	Clock           Clock       // Clock used by the time-based nodes of the tree that don't have their own. If nil, the real time is used.
+	Tracer          Tracer      // Tracer that records every tick as a span. If nil, the Tracer carried by the tick's context, if any, is used.
+	status          Status
+	boundRoot       Node        // Root refreshed by the last Refresh
+	boundBlackboard *Blackboard // Blackboard bound to the nodes of the tree by the last Refresh
+	mu              sync.Mutex  // Protects observers
+	observers       []*Observer // Observers added with AddObserver, by the address of their registration
 }
 
 // New creates a new BehaviorTree with the given root node.
@@ -66,24 +130,135 @@ type BehaviorTree struct {
 //   - root: The root node of the behavior tree. This can be any node that implements the Node interface.
 //
 // Returns:
-//   - A pointer to a new BehaviorTree instance initialized with the provided root node and a status of Ready.
+//   - A pointer to a new BehaviorTree instance initialized with the provided root node, an empty Blackboard
+//     and a status of Ready. The tree is prepared for its first tick with Refresh.
 func New(root Node) *BehaviorTree {
-	return &BehaviorTree{Root: root, status: Ready}
+	bt := &BehaviorTree{Root: root, Blackboard: NewBlackboard(), status: Ready}
+	return bt.Refresh()
 }
 
-// Tick executes the behavior tree.
+// Tick executes the behavior tree with a background context.
 //
 // Returns:
 //   - The current status of the behavior tree after execution.
 func (bt *BehaviorTree) Tick() Status {
-	if bt.Root == nil {
+	return bt.TickContext(context.Background())
+}
+
+// TickContext executes the behavior tree with the given context. If Root or Blackboard has changed since the
+// last tick, the tree is first prepared again with Refresh. The tree's Blackboard is added to the context, as are
+// the tree's Clock and Tracer if it has them and the observers added with AddObserver. If the tick is traced, the
+// root node is ticked within a "BehaviorTree.Tick" span (see Tracer). Once the tick is over, the observers that
+// implement TreeObserver are notified, even if the tree failed without ticking its root.
+//
+// Parameters:
+//   - ctx: The context for this tick. It is passed to every node in the tree. If ctx is cancelled,
+//     the tree fails without ticking its root node.
+//
+// Returns:
+//   - The current status of the behavior tree after execution.
+func (bt *BehaviorTree) TickContext(ctx context.Context) Status {
+	bt.mu.Lock()
+	registered := make(observers, 0, len(bt.observers))
+	for _, observer := range bt.observers {
+		registered = append(registered, *observer)
+	}
+	bt.mu.Unlock()
+
+	clock := bt.Clock
+	if clock == nil {
+		clock = ClockFromContext(ctx)
+	}
+	event := TickEvent{Node: bt.Root, Name: NameOf(bt.Root), Path: "root", Previous: bt.status, Start: clock.Now()}
+	event.Status = bt.tick(ctx, registered)
+	event.Duration = clock.Now().Sub(event.Start)
+	for _, observer := range registered {
+		if tree, ok := observer.(TreeObserver); ok {
+			tree.OnTick(event)
+		}
+	}
+	return event.Status
+}
+
+// tick ticks the root node of the tree with the context prepared for the given observers.
+func (bt *BehaviorTree) tick(ctx context.Context, registered observers) Status {
+	if bt.Root == nil || ctx.Err() != nil {
 		bt.status = Failure
 		return Failure
 	}
-	bt.status = bt.Root.Tick()
+	if bt.Blackboard == nil {
+		bt.Blackboard = NewBlackboard()
+	}
+	if bt.boundBlackboard != bt.Blackboard || !sameNode(bt.boundRoot, bt.Root) {
+		bt.Refresh()
+	}
+	ctx = WithBlackboard(ctx, bt.Blackboard)
+	if bt.Clock != nil {
+		ctx = WithClock(ctx, bt.Clock)
+	}
+	for _, observer := range registered {
+		ctx = WithObserver(ctx, observer)
+	}
+	if bt.Tracer != nil {
+		ctx = WithTracer(ctx, bt.Tracer)
+	}
+	if tracer := TracerFromContext(ctx); tracer != nil {
+		ctx, span := tracer.Start(ctx, treeSpanName)
+		bt.status = TickNode(ctx, bt.Root)
+		endSpan(span, bt.status)
+		span.End()
+		return bt.status
+	}
+	bt.status = TickNode(ctx, bt.Root)
 	return bt.status
 }
 
+// AddObserver registers an Observer that receives an event when each node of the tree starts and finishes a
+// tick, starting with the next tick. See Observer.
+//
+// Parameters:
+//   - observer: The Observer to register.
+//
+// Returns:
+//   - A function that unregisters the Observer. Calling it more than once has no effect.
+func (bt *BehaviorTree) AddObserver(observer Observer) func() {
+	registration := &observer
+	bt.mu.Lock()
+	defer bt.mu.Unlock()
+	bt.observers = append(bt.observers, registration)
+	return func() {
+		bt.mu.Lock()
+		defer bt.mu.Unlock()
+		for i, registered := range bt.observers {
+			if registered == registration {
+				bt.observers = append(bt.observers[:i:i], bt.observers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Refresh assigns the paths of the nodes in the tree with AssignPaths and binds the tree's Blackboard to every
+// node in the tree that implements BlackboardUser. Tick does this itself when Root or Blackboard is replaced, but
+// a tree whose nodes are added, removed or moved below its root must be refreshed before its next tick, so that
+// the paths and the Blackboard of its nodes stay up to date.
+//
+// Returns:
+//   - A pointer to the BehaviorTree instance after refreshing, allowing for method chaining.
+func (bt *BehaviorTree) Refresh() *BehaviorTree {
+	AssignPaths(bt.Root)
+	bindBlackboard(bt.Root, bt.Blackboard)
+	bt.boundRoot, bt.boundBlackboard = bt.Root, bt.Blackboard
+	return bt
+}
+
+// sameNode reports whether a and b are the same node. Nodes whose type is not comparable are never the same, so
+// that a tree with such a root is refreshed before every tick.
+func sameNode(a, b Node) bool {
+	kind := reflect.TypeOf(a)
+	return kind != nil && kind == reflect.TypeOf(b) && kind.Comparable() && a == b
+}
+
 // Reset resets the behavior tree to its initial state.
 //
 // Returns:
@@ -96,6 +271,18 @@ func (bt *BehaviorTree) Reset() *BehaviorTree {
 	return bt
 }
 
+// Halt interrupts the behavior tree, halting every Running node in the tree.
+//
+// Returns:
+//   - A pointer to the BehaviorTree instance after halting, allowing for method chaining.
+func (bt *BehaviorTree) Halt() *BehaviorTree {
+	if bt.Root != nil {
+		HaltNode(bt.Root)
+	}
+	bt.status = Ready
+	return bt
+}
+
 // Status returns the current status of the behavior tree.
 //
 // Returns:
@@ -122,22 +309,58 @@ func (bt *BehaviorTree) String() string {
 }
 
 // Action is a leaf node that performs an action.
+// The action is performed by the first of Run, RunContext and RunWithBlackboard that is not nil.
 type Action struct {
-	Run    func() Status
-	status Status
+	Name              string // Optional name that identifies the action in tree definitions, String(), logs and tooling
+	Run               func() Status
+	RunContext        func(ctx context.Context) Status // Optional Run variant that receives the tick's context
+	RunWithBlackboard func(bb *Blackboard) Status      // Optional Run variant that receives the tree's Blackboard
+	OnHalt            func()                           // Optional callback invoked when the action is halted while Running
+	blackboard        *Blackboard
+	status            Status
+	path              string // Path of the node in its tree, assigned by AssignPaths
 }
 
-// Tick executes the action's Run function and handles all status values.
+// Tick executes the action with a background context.
 //
 // Returns:
-//   - The current status of the Action node after execution, which can be Ready, Running, Success, or Failure.
-//     If the Run function is nil or returns an invalid status, it defaults to Failure.
+//   - The current status of the Action node after execution. See TickContext for details.
 func (a *Action) Tick() Status {
-	if a.Run == nil {
+	return a.TickContext(context.Background())
+}
+
+// TickContext executes the action's Run function and handles all status values. While a Replay is in progress,
+// the action returns its recorded result instead of running.
+//
+// Parameters:
+//   - ctx: The context for this tick. It is passed to RunContext. If ctx is cancelled, the action
+//     fails without running.
+//
+// Returns:
+//   - The current status of the Action node after execution, which can be Ready, Running, Success, or Failure.
+//     If no run function is set or the function returns an invalid status, it defaults to Failure.
+func (a *Action) TickContext(ctx context.Context) Status {
+	if ctx.Err() != nil {
+		a.status = Failure
+		return a.status
+	}
+	if status, ok := replayResult(ctx, a); ok {
+		a.status = status
+		return a.status
+	}
+
+	var status Status
+	switch {
+	case a.Run != nil:
+		status = a.Run()
+	case a.RunContext != nil:
+		status = a.RunContext(ctx)
+	case a.RunWithBlackboard != nil:
+		status = a.RunWithBlackboard(a.blackboard)
+	default:
 		a.status = Failure
 		return a.status
 	}
-	status := a.Run()
 	switch status {
 	case Ready, Running, Success, Failure:
 		a.status = status
@@ -148,6 +371,14 @@ func (a *Action) Tick() Status {
 	}
 }
 
+// SetBlackboard binds the Blackboard passed to RunWithBlackboard. It is called by BehaviorTree.Tick.
+//
+// Parameters:
+//   - bb: The Blackboard to bind to the Action node.
+func (a *Action) SetBlackboard(bb *Blackboard) {
+	a.blackboard = bb
+}
+
 // Reset resets the Action node to its initial state.
 //
 // Returns:
@@ -157,6 +388,19 @@ func (a *Action) Reset() Status {
 	return a.status
 }
 
+// Halt interrupts the Action node. If the action is Running, OnHalt is called so the action can
+// release any resources it holds.
+//
+// Returns:
+//   - The status of the Action node after halting, which will be Ready.
+func (a *Action) Halt() Status {
+	if a.status == Running && a.OnHalt != nil {
+		a.OnHalt()
+	}
+	a.status = Ready
+	return a.status
+}
+
 // Status returns the current status of the Action node.
 //
 // Returns:
@@ -168,24 +412,81 @@ func (a *Action) Status() Status {
 // String returns a string representation of the Action node.
 //
 // Returns:
-//   - A string that represents the Action node, including its current status. The format is "Action (Status)".
+//   - A string that represents the Action node, including its current status. The format is "Action (Status)", with the name and path of the node after its type when they are set.
 func (a *Action) String() string {
 	var builder strings.Builder
-	builder.WriteString("Action (" + a.Status().String() + ")")
+	builder.WriteString(nodeLabel("Action", a.Name, a.path) + " (" + a.Status().String() + ")")
 	return builder.String()
 }
 
+// NodeName returns the optional name of the Action node.
+//
+// Returns:
+//   - The Name of the node, or the empty string if it has none.
+func (a *Action) NodeName() string {
+	return a.Name
+}
+
+// Path returns the path of the Action node in its tree, such as "root/0/2".
+//
+// Returns:
+//   - The path assigned by AssignPaths, or the empty string if none has been assigned.
+func (a *Action) Path() string {
+	return a.path
+}
+
+// SetPath sets the path of the Action node in its tree. It is called by AssignPaths.
+//
+// Parameters:
+//   - path: The path of the node.
+func (a *Action) SetPath(path string) {
+	a.path = path
+}
+
 // Condition is a leaf node that checks a condition.
+// The condition is evaluated by the first of Check, CheckContext and CheckWithBlackboard that is not nil.
 type Condition struct {
-	Check func() bool
+	Name                string // Optional name that identifies the condition in tree definitions, String(), logs and tooling
+	Check               func() bool
+	CheckContext        func(ctx context.Context) bool // Optional Check variant that receives the tick's context
+	CheckWithBlackboard func(bb *Blackboard) bool      // Optional Check variant that receives the tree's Blackboard
+	blackboard          *Blackboard
+	path                string // Path of the node in its tree, assigned by AssignPaths
 }
 
-// Tick executes the condition's Check function.
+// Tick executes the condition's Check function with a background context.
 //
 // Returns:
 //   - Success if the Check function returns true, Failure if it returns false or is nil.
 func (c *Condition) Tick() Status {
-	return c.Status()
+	return c.TickContext(context.Background())
+}
+
+// TickContext executes the condition's Check function. While a Replay is in progress, the condition returns
+// its recorded result instead of being checked.
+//
+// Parameters:
+//   - ctx: The context for this tick. It is passed to CheckContext. If ctx is cancelled, the condition
+//     fails without being checked.
+//
+// Returns:
+//   - Success if the Check function returns true, Failure if it returns false or is nil.
+func (c *Condition) TickContext(ctx context.Context) Status {
+	if ctx.Err() != nil {
+		return Failure
+	}
+	if status, ok := replayResult(ctx, c); ok {
+		return status
+	}
+	return c.evaluate(ctx)
+}
+
+// SetBlackboard binds the Blackboard passed to CheckWithBlackboard. It is called by BehaviorTree.Tick.
+//
+// Parameters:
+//   - bb: The Blackboard to bind to the Condition node.
+func (c *Condition) SetBlackboard(bb *Blackboard) {
+	c.blackboard = bb
 }
 
 // Reset resets the Condition node to its initial state.
@@ -197,16 +498,39 @@ func (c *Condition) Reset() Status {
 	return Ready
 }
 
+// Halt interrupts the Condition node. Since Condition nodes are stateless and never Running,
+// this method simply returns Ready.
+//
+// Returns:
+//   - The status of the Condition node after halting, which will be Ready.
+func (c *Condition) Halt() Status {
+	return Ready
+}
+
 // Status returns the current status of the Condition node.
 //
 // Returns:
 //   - Success if the Check function returns true, Failure if it returns false or is nil. Since Condition nodes are stateless,
-//     this method directly evaluates the Check function each time it's called.
+//     this method directly evaluates the Check function each time it's called, using a background context.
 func (c *Condition) Status() Status {
-	if c.Check == nil {
-		return Failure
-	}
-	if c.Check() {
+	return c.evaluate(context.Background())
+}
+
+// evaluate runs the condition's Check function with the given context.
+//
+// Returns:
+//   - Success if the Check function returns true, Failure if it returns false or is nil.
+func (c *Condition) evaluate(ctx context.Context) Status {
+	var ok bool
+	switch {
+	case c.Check != nil:
+		ok = c.Check()
+	case c.CheckContext != nil:
+		ok = c.CheckContext(ctx)
+	case c.CheckWithBlackboard != nil:
+		ok = c.CheckWithBlackboard(c.blackboard)
+	}
+	if ok {
 		return Success
 	}
 	return Failure
@@ -215,26 +539,71 @@ func (c *Condition) Status() Status {
 // String returns a string representation of the Condition node.
 //
 // Returns:
-//   - A string that represents the Condition node, including its current status. The format is "Condition (Status)".
+//   - A string that represents the Condition node, including its current status. The format is "Condition (Status)", with the name and path of the node after its type when they are set.
 func (c *Condition) String() string {
 	var builder strings.Builder
-	builder.WriteString("Condition (" + c.Status().String() + ")")
+	builder.WriteString(nodeLabel("Condition", c.Name, c.path) + " (" + c.Status().String() + ")")
 	return builder.String()
 }
 
+// NodeName returns the optional name of the Condition node.
+//
+// Returns:
+//   - The Name of the node, or the empty string if it has none.
+func (c *Condition) NodeName() string {
+	return c.Name
+}
+
+// Path returns the path of the Condition node in its tree, such as "root/0/2".
+//
+// Returns:
+//   - The path assigned by AssignPaths, or the empty string if none has been assigned.
+func (c *Condition) Path() string {
+	return c.path
+}
+
+// SetPath sets the path of the Condition node in its tree. It is called by AssignPaths.
+//
+// Parameters:
+//   - path: The path of the node.
+func (c *Condition) SetPath(path string) {
+	c.path = path
+}
+
 // Composite is a node that combines multiple conditions with any other node.
 // It first checks all conditions, and if they all succeed, runs the child node.
+// If a condition stops succeeding while the child node is Running, the child node is halted.
 type Composite struct {
-	Conditions []Node
-	Child      Node
-	status     Status
+	Name         string // Optional name that identifies the node in String(), logs and tooling
+	Conditions   []Node
+	Child        Node
+	status       Status
+	childRunning bool   // Whether the child node was Running after the last tick
+	path         string // Path of the node in its tree, assigned by AssignPaths
 }
 
-// Tick executes the composite by first checking all conditions, then running the child node if all conditions succeed.
+// Tick executes the Composite node with a background context.
 //
 // Returns:
-//   - The current status of the Composite node after execution, which can be Ready, Running, Success, or Failure.
+//   - The status of the Composite node after execution. See TickContext for details.
 func (c *Composite) Tick() Status {
+	return c.TickContext(context.Background())
+}
+
+// TickContext executes the composite by first checking all conditions, then running the child node if all conditions succeed.
+//
+// Parameters:
+//   - ctx: The context for this tick. It is passed to the children of the node.
+//     If ctx is cancelled, the node fails without ticking its children.
+//
+// Returns:
+//   - The current status of the Composite node after execution, which can be Ready, Running, Success, or Failure.
+func (c *Composite) TickContext(ctx context.Context) Status {
+	if ctx.Err() != nil {
+		c.status = Failure
+		return c.status
+	}
+
 	if len(c.Conditions) == 0 && c.Child == nil {
 		c.status = Failure
 		return c.status
@@ -243,7 +612,8 @@ func (c *Composite) Tick() Status {
 	// If no conditions, just run the child node
 	if len(c.Conditions) == 0 {
 		if c.Child != nil {
-			c.status = c.Child.Tick()
+			c.status = TickNode(ctx, c.Child)
+			c.childRunning = c.status == Running
 			return c.status
 		}
 		c.status = Failure
@@ -252,20 +622,23 @@ func (c *Composite) Tick() Status {
 
 	// Check all conditions first (like a sequence - all must succeed)
 	for _, condition := range c.Conditions {
-		conditionStatus := condition.Tick()
+		conditionStatus := TickNode(ctx, condition)
 		switch conditionStatus {
 		case Success:
 			// This condition succeeded, continue to next condition
 			continue
 		case Running:
-			// This condition is still running
+			// This condition is still running, so the child can no longer run
+			c.haltChild()
 			c.status = Running
 			return c.status
 		case Failure, Ready:
 			// This condition failed or not ready, composite fails
+			c.haltChild()
 			c.status = Failure
 			return c.status
 		default:
+			c.haltChild()
 			c.status = Failure
 			return c.status
 		}
@@ -273,13 +646,22 @@ func (c *Composite) Tick() Status {
 
 	// All conditions succeeded, run the child node
 	if c.Child != nil {
-		c.status = c.Child.Tick()
+		c.status = TickNode(ctx, c.Child)
+		c.childRunning = c.status == Running
 		return c.status
 	}
 	c.status = Success
 	return c.status
 }
 
+// haltChild halts the child node if it was Running after the last tick.
+func (c *Composite) haltChild() {
+	if c.childRunning && c.Child != nil {
+		HaltNode(c.Child)
+	}
+	c.childRunning = false
+}
+
 // Reset resets the Composite node and its conditions and child node to their initial state.
 //
 // Returns:
@@ -292,6 +674,24 @@ func (c *Composite) Reset() Status {
 	if c.Child != nil {
 		c.Child.Reset()
 	}
+	c.childRunning = false
+	c.status = Ready
+	return c.status
+}
+
+// Halt interrupts the Composite node, halting its conditions and child node.
+//
+// Returns:
+//   - The status of the Composite node after halting, which will be Ready. Running conditions and child nodes
+//     are halted, while all others are reset.
+func (c *Composite) Halt() Status {
+	for i := range c.Conditions {
+		HaltNode(c.Conditions[i])
+	}
+	if c.Child != nil {
+		HaltNode(c.Child)
+	}
+	c.childRunning = false
 	c.status = Ready
 	return c.status
 }
@@ -309,10 +709,10 @@ func (c *Composite) Status() Status {
 //
 // Returns:
 //   - A string that represents the Composite node, including its current status, all conditions, and the child node (if it exists)
-//     The format is: Composite (Status)
+//     The format is: Composite (Status), with the name and path of the node after its type when they are set.
 func (c *Composite) String() string {
 	var builder strings.Builder
-	builder.WriteString("Composite (" + c.Status().String() + ")")
+	builder.WriteString(nodeLabel("Composite", c.Name, c.path) + " (" + c.Status().String() + ")")
 	for i := range c.Conditions {
 		builder.WriteString("\n  Condition[" + strconv.Itoa(i) + "]: " + c.Conditions[i].String())
 	}
@@ -328,11 +728,50 @@ func (c *Composite) String() string {
 	return builder.String()
 }
 
+// NodeName returns the optional name of the Composite node.
+//
+// Returns:
+//   - The Name of the node, or the empty string if it has none.
+func (c *Composite) NodeName() string {
+	return c.Name
+}
+
+// Path returns the path of the Composite node in its tree, such as "root/0/2".
+//
+// Returns:
+//   - The path assigned by AssignPaths, or the empty string if none has been assigned.
+func (c *Composite) Path() string {
+	return c.path
+}
+
+// SetPath sets the path of the Composite node in its tree. It is called by AssignPaths.
+//
+// Parameters:
+//   - path: The path of the node.
+func (c *Composite) SetPath(path string) {
+	c.path = path
+}
+
+// ChildNodes returns the conditions of the Composite node followed by its child.
+//
+// Returns:
+//   - The conditions and the child of the node, in the order in which they are ticked.
+func (c *Composite) ChildNodes() []Node {
+	nodes := make([]Node, 0, len(c.Conditions)+1)
+	nodes = append(nodes, c.Conditions...)
+	return append(nodes, childSlice(c.Child)...)
+}
+
 // Selector is a Node that runs its children in order and succeeds if at least one child succeeds.
 // The Selector composite type can be seen as an OR operator with their children.
+// Every tick starts again at the first child; if a higher-priority child succeeds or is Running,
+// the lower-priority child that was previously Running is halted.
 type Selector struct {
+	Name     string // Optional name that identifies the node in String(), logs and tooling
 	Children []Node
 	status   Status
+	running  int    // One more than the index of the child that was Running after the last tick, or 0 if none
+	path     string // Path of the node in its tree, assigned by AssignPaths
 }
 
 // Reset resets the Selector node and all its children to their initial state.
@@ -343,32 +782,97 @@ func (s *Selector) Reset() Status {
 	for _, child := range s.Children {
 		child.Reset()
 	}
+	s.running = 0
+	s.status = Ready
+	return s.status
+}
+
+// Halt interrupts the Selector node and all its children.
+//
+// Returns:
+//   - The status of the Selector node after halting, which will be Ready. Running child nodes are halted,
+//     while all others are reset.
+func (s *Selector) Halt() Status {
+	for _, child := range s.Children {
+		HaltNode(child)
+	}
+	s.running = 0
 	s.status = Ready
 	return s.status
 }
 
-// Tick executes the selector and handles all status values.
+// Tick executes the Selector node with a background context.
+//
+// Returns:
+//   - The status of the Selector node after execution. See TickContext for details.
+func (s *Selector) Tick() Status {
+	return s.TickContext(context.Background())
+}
+
+// TickContext executes the selector and handles all status values.
+//
+// Parameters:
+//   - ctx: The context for this tick. It is passed to the children of the node.
+//     If ctx is cancelled, the node fails without ticking its children.
 //
 // Returns:
 //   - The status of the Selector node after execution, which can be Ready, Running, Success, or Failure.
 //     The Selector returns Success if at least one child returns Success, Running if at least one child is
 //     Running and none have succeeded, and Failure if all children have failed or are not ready.
-func (s *Selector) Tick() Status {
-	for _, child := range s.Children {
-		status := child.Tick()
+func (s *Selector) TickContext(ctx context.Context) Status {
+	s.status = tickSelector(ctx, s.Children, &s.running)
+	return s.status
+}
+
+// tickSelector ticks the children of a Selector or ReactiveSelector in order, starting again at the first child,
+// until one of them doesn't fail.
+//
+// Parameters:
+//   - ctx: The context for this tick. If it is cancelled, no child is ticked.
+//   - children: The children of the selector.
+//   - running: One more than the index of the child that was Running after the last tick, or 0 if none. It is
+//     updated for this tick.
+//
+// Returns:
+//   - The status of the first child that doesn't fail, or Failure if they all fail.
+func tickSelector(ctx context.Context, children []Node, running *int) Status {
+	if ctx.Err() != nil {
+		return Failure
+	}
+
+	for i, child := range children {
+		status := TickNode(ctx, child)
 		switch status {
 		case Failure:
 			continue
 		case Ready, Running, Success:
-			s.status = status
-			return s.status
+			// A higher-priority child took over, so halt the child that was previously running
+			haltPreempted(children, *running, i)
+			*running = 0
+			if status == Running {
+				*running = i + 1
+			}
+			return status
 		default:
-			s.status = Failure
-			return s.status
+			*running = 0
+			return Failure
 		}
 	}
-	s.status = Failure
-	return s.status
+	*running = 0
+	return Failure
+}
+
+// haltPreempted halts the child that was Running after the last tick if it is not the child currently
+// deciding the outcome of the composite.
+//
+// Parameters:
+//   - children: The children of the composite node.
+//   - running: One more than the index of the child that was Running after the last tick, or 0 if none.
+//   - current: The index of the child currently deciding the outcome of the composite.
+func haltPreempted(children []Node, running, current int) {
+	if running != 0 && running-1 != current && running-1 < len(children) {
+		HaltNode(children[running-1])
+	}
 }
 
 // Status returns the current status of the Selector node.
@@ -382,7 +886,7 @@ func (s *Selector) Status() Status {
 // String returns a string representation of the Selector node.
 func (s *Selector) String() string {
 	var builder strings.Builder
-	builder.WriteString("Selector (" + s.Status().String() + ")")
+	builder.WriteString(nodeLabel("Selector", s.Name, s.path) + " (" + s.Status().String() + ")")
 	for _, child := range s.Children {
 		str := child.String()
 		lines := strings.Split(str, "\n")
@@ -394,13 +898,47 @@ func (s *Selector) String() string {
 	return builder.String()
 }
 
+// NodeName returns the optional name of the Selector node.
+//
+// Returns:
+//   - The Name of the node, or the empty string if it has none.
+func (s *Selector) NodeName() string {
+	return s.Name
+}
+
+// Path returns the path of the Selector node in its tree, such as "root/0/2".
+//
+// Returns:
+//   - The path assigned by AssignPaths, or the empty string if none has been assigned.
+func (s *Selector) Path() string {
+	return s.path
+}
+
+// SetPath sets the path of the Selector node in its tree. It is called by AssignPaths.
+//
+// Parameters:
+//   - path: The path of the node.
+func (s *Selector) SetPath(path string) {
+	s.path = path
+}
+
+// ChildNodes returns the children of the Selector node.
+//
+// Returns:
+//   - The Children of the node, in order.
+func (s *Selector) ChildNodes() []Node {
+	return s.Children
+}
+
 // Sequence is a Node that runs its children in order and succeeds if all children succeed.
 // The Sequence composite type can be seen as an AND operator with their children.
 // It tracks the last non-successful node and only runs nodes that haven't previously completed successfully.
 type Sequence struct {
+	Name                string // Optional name that identifies the node in String(), logs and tooling
 	Children            []Node
 	status              Status
-	lastNonSuccessIndex int // Track the index of the last non-successful child
+	lastNonSuccessIndex int    // Track the index of the last non-successful child
+	path                string // Path of the node in its tree, assigned by AssignPaths
 }
 
 // Reset resets the Sequence node and all its children to their initial state.
@@ -417,16 +955,47 @@ func (s *Sequence) Reset() Status {
 	return s.status
 }
 
-// Tick runs the sequence and handles all status values.
+// Halt interrupts the Sequence node and all its children.
+//
+// Returns:
+//   - The status of the Sequence node after halting, which will be Ready. Running child nodes are halted,
+//     while all others are reset, and the tracking of successful nodes is reset.
+func (s *Sequence) Halt() Status {
+	for _, child := range s.Children {
+		HaltNode(child)
+	}
+	s.status = Ready
+	s.lastNonSuccessIndex = 0
+	return s.status
+}
+
+// Tick executes the Sequence node with a background context.
+//
+// Returns:
+//   - The status of the Sequence node after execution. See TickContext for details.
+func (s *Sequence) Tick() Status {
+	return s.TickContext(context.Background())
+}
+
+// TickContext runs the sequence and handles all status values.
 // It keeps track of the last non-successful child and only runs children that haven't previously completed successfully.
 //
+// Parameters:
+//   - ctx: The context for this tick. It is passed to the children of the node.
+//     If ctx is cancelled, the node fails without ticking its children.
+//
 // Returns:
 //   - The status of the Sequence node after execution, which can be Ready, Running, Success, or Failure.
-func (s *Sequence) Tick() Status {
+func (s *Sequence) TickContext(ctx context.Context) Status {
+	if ctx.Err() != nil {
+		s.status = Failure
+		return s.status
+	}
+
 	// Start from the last non-successful child index
 	for i := s.lastNonSuccessIndex; i < len(s.Children); i++ {
 		child := s.Children[i]
-		status := child.Tick()
+		status := TickNode(ctx, child)
 		switch status {
 		case Success:
 			// This child succeeded, move to next child on next tick
@@ -456,10 +1025,10 @@ func (s *Sequence) Status() Status {
 //
 // Returns:
 //   - A string that represents the Sequence node, including its current status and all child nodes.
-//     The format is: Sequence (Status)
+//     The format is: Sequence (Status), with the name and path of the node after its type when they are set.
 func (s *Sequence) String() string {
 	var builder strings.Builder
-	builder.WriteString("Sequence (" + s.Status().String() + ")")
+	builder.WriteString(nodeLabel("Sequence", s.Name, s.path) + " (" + s.Status().String() + ")")
 	for _, child := range s.Children {
 		str := child.String()
 		lines := strings.Split(str, "\n")
@@ -471,15 +1040,86 @@ func (s *Sequence) String() string {
 	return builder.String()
 }
 
-// Parallel is a Node that runs all its children in parallel and returns Success
-// if at least M children report Success, where M is specified by MinSuccessCount.
-type Parallel struct {
-	Children        []Node
-	MinSuccessCount int
-	status          Status
+// NodeName returns the optional name of the Sequence node.
+//
+// Returns:
+//   - The Name of the node, or the empty string if it has none.
+func (s *Sequence) NodeName() string {
+	return s.Name
 }
 
-// Reset resets the Parallel node and all its children to their initial state.
+// Path returns the path of the Sequence node in its tree, such as "root/0/2".
+//
+// Returns:
+//   - The path assigned by AssignPaths, or the empty string if none has been assigned.
+func (s *Sequence) Path() string {
+	return s.path
+}
+
+// SetPath sets the path of the Sequence node in its tree. It is called by AssignPaths.
+//
+// Parameters:
+//   - path: The path of the node.
+func (s *Sequence) SetPath(path string) {
+	s.path = path
+}
+
+// ChildNodes returns the children of the Sequence node.
+//
+// Returns:
+//   - The Children of the node, in order.
+func (s *Sequence) ChildNodes() []Node {
+	return s.Children
+}
+
+// ParallelPolicy determines how a parallel node decides its outcome from the statuses of its children.
+type ParallelPolicy int
+
+const (
+	// ParallelThreshold succeeds once MinSuccessCount children succeed, and fails once MinFailureCount
+	// children fail or MinSuccessCount can no longer be reached.
+	ParallelThreshold ParallelPolicy = iota
+	// ParallelAll succeeds once every child succeeds, and fails as soon as any child fails.
+	ParallelAll
+	// ParallelAny succeeds as soon as any child succeeds, and fails once every child fails.
+	ParallelAny
+	// ParallelRace returns the status of the first child to finish with Success or Failure.
+	ParallelRace
+)
+
+// String returns the string representation of the ParallelPolicy.
+func (pp ParallelPolicy) String() string {
+	switch pp {
+	case ParallelThreshold:
+		return "Threshold"
+	case ParallelAll:
+		return "All"
+	case ParallelAny:
+		return "Any"
+	case ParallelRace:
+		return "Race"
+	default:
+		return "Unknown"
+	}
+}
+
+// Parallel is a Node that runs all its children in parallel and decides its outcome according to Policy.
+// With the default ParallelThreshold policy, it returns Success if at least M children report Success,
+// where M is specified by MinSuccessCount, and Failure once MinFailureCount children report Failure or M
+// can no longer be reached. Once the outcome is decided, children that are still Running are halted
+// unless KeepRunning is set.
+type Parallel struct {
+	Name            string // Optional name that identifies the node in String(), logs and tooling
+	Children        []Node
+	Policy          ParallelPolicy // How the outcome is decided. Defaults to ParallelThreshold
+	MinSuccessCount int            // Successes required by ParallelThreshold. If zero, one success is required
+	MinFailureCount int            // Failures that fail ParallelThreshold. If zero, fails only once MinSuccessCount is unreachable
+	KeepRunning     bool           // If true, children still Running once the outcome is decided are not halted
+	status          Status
+	path            string // Path of the node in its tree, assigned by AssignPaths
+}
+
+// Reset resets the Parallel node and all its children to their initial state.
 //
 // Returns:
 //   - The status of the Parallel node after reset, which will be Ready. This method resets all child nodes
@@ -492,66 +1132,176 @@ func (p *Parallel) Reset() Status {
 	return p.status
 }
 
-// Tick runs all children in parallel and evaluates based on MinSuccessCount.
+// Halt interrupts the Parallel node and all its children.
 //
 // Returns:
-//   - The status of the Parallel node after execution, which can be Ready, Running, Success, or Failure.
+//   - The status of the Parallel node after halting, which will be Ready. Running child nodes are halted,
+//     while all others are reset.
+func (p *Parallel) Halt() Status {
+	for _, child := range p.Children {
+		HaltNode(child)
+	}
+	p.status = Ready
+	return p.status
+}
+
+// Tick executes the Parallel node with a background context.
+//
+// Returns:
+//   - The status of the Parallel node after execution. See TickContext for details.
 func (p *Parallel) Tick() Status {
+	return p.TickContext(context.Background())
+}
+
+// TickContext runs all children in parallel and evaluates the results based on the Policy. If the configuration
+// of the node is invalid (see Validate), the node fails without ticking its children.
+//
+// Parameters:
+//   - ctx: The context for this tick. It is passed to the children of the node.
+//     If ctx is cancelled, the node fails without ticking its children.
+//
+// Returns:
+//   - The status of the Parallel node after execution, which can be Ready, Running, Success, or Failure.
+func (p *Parallel) TickContext(ctx context.Context) Status {
+	if ctx.Err() != nil {
+		p.status = Failure
+		return p.status
+	}
+
 	if len(p.Children) == 0 {
 		p.status = Success
 		return p.status
 	}
 
-	// Validate MinSuccessCount
-	if p.MinSuccessCount <= 0 {
-		p.MinSuccessCount = 1
+	rule := parallelRule{policy: p.Policy, minSuccessCount: p.MinSuccessCount, minFailureCount: p.MinFailureCount}
+	if rule.validate("Parallel", len(p.Children)) != nil {
+		p.status = Failure
+		return p.status
+	}
+
+	// Tick all children, recording the order in which they finish
+	statuses := make([]Status, len(p.Children))
+	finished := make([]int, 0, len(p.Children))
+	for i, child := range p.Children {
+		statuses[i] = TickNode(ctx, child)
+		if statuses[i] == Success || statuses[i] == Failure {
+			finished = append(finished, i)
+		}
+	}
+
+	p.status = rule.outcome(statuses, finished)
+	if p.status != Running && !p.KeepRunning {
+		haltRunning(p.Children, statuses)
+	}
+	return p.status
+}
+
+// Validate checks the configuration of the Parallel node. Parallel never modifies its configuration; an invalid
+// configuration instead makes every tick fail.
+//
+// Returns:
+//   - nil if the configuration is valid, or an error wrapping ErrInvalidConfig describing the problem.
+func (p *Parallel) Validate() error {
+	rule := parallelRule{policy: p.Policy, minSuccessCount: p.MinSuccessCount, minFailureCount: p.MinFailureCount}
+	return rule.validate("Parallel", len(p.Children))
+}
+
+// parallelRule holds the configuration shared by the parallel node types that decides their outcome.
+type parallelRule struct {
+	policy          ParallelPolicy
+	minSuccessCount int
+	minFailureCount int
+}
+
+// validate checks the rule against the number of children of the node.
+//
+// Returns:
+//   - nil if the rule is valid, or an error wrapping ErrInvalidConfig describing the problem.
+func (r parallelRule) validate(nodeType string, childCount int) error {
+	switch r.policy {
+	case ParallelThreshold, ParallelAll, ParallelAny, ParallelRace:
+	default:
+		return fmt.Errorf("%w: %s has unknown policy %d", ErrInvalidConfig, nodeType, int(r.policy))
 	}
-	if p.MinSuccessCount > len(p.Children) {
-		p.MinSuccessCount = len(p.Children)
+	if r.minSuccessCount < 0 || r.minSuccessCount > childCount {
+		return fmt.Errorf("%w: %s MinSuccessCount %d must be between 0 and the number of children (%d)",
+			ErrInvalidConfig, nodeType, r.minSuccessCount, childCount)
+	}
+	if r.minFailureCount < 0 || r.minFailureCount > childCount {
+		return fmt.Errorf("%w: %s MinFailureCount %d must be between 0 and the number of children (%d)",
+			ErrInvalidConfig, nodeType, r.minFailureCount, childCount)
+	}
+	return nil
+}
+
+// outcome determines the status of a parallel node from the statuses of its children.
+//
+// Parameters:
+//   - statuses: The status of each child after this tick.
+//   - finished: The indexes of the children that returned Success or Failure, in the order they finished.
+//
+// Returns:
+//   - Success or Failure once the outcome is decided according to the policy, and Running otherwise.
+func (r parallelRule) outcome(statuses []Status, finished []int) Status {
+	if r.policy == ParallelRace {
+		if len(finished) > 0 {
+			return statuses[finished[0]]
+		}
+		return Running
 	}
 
 	successCount := 0
 	failureCount := 0
 	runningCount := 0
-
-	// Tick all children
-	for _, child := range p.Children {
-		status := child.Tick()
+	for _, status := range statuses {
 		switch status {
 		case Success:
 			successCount++
 		case Failure:
 			failureCount++
-		case Running:
-			runningCount++
-		case Ready:
+		case Running, Ready:
 			// Treat Ready as still processing
 			runningCount++
 		}
 	}
 
+	minSuccessCount, minFailureCount := r.minSuccessCount, r.minFailureCount
+	switch r.policy {
+	case ParallelAll:
+		minSuccessCount, minFailureCount = len(statuses), 1
+	case ParallelAny:
+		minSuccessCount, minFailureCount = 1, len(statuses)
+	}
+	if minSuccessCount == 0 {
+		minSuccessCount = 1
+	}
+
 	// Check if we have enough successes
-	if successCount >= p.MinSuccessCount {
-		p.status = Success
-		return p.status
+	if successCount >= minSuccessCount {
+		return Success
+	}
+
+	// Check if we have too many failures
+	if minFailureCount > 0 && failureCount >= minFailureCount {
+		return Failure
 	}
 
 	// Check if we can never reach MinSuccessCount (too many failures)
-	maxPossibleSuccesses := successCount + runningCount
-	if maxPossibleSuccesses < p.MinSuccessCount {
-		p.status = Failure
-		return p.status
+	if successCount+runningCount < minSuccessCount {
+		return Failure
 	}
 
 	// Still have a chance to succeed, keep running
-	if runningCount > 0 {
-		p.status = Running
-		return p.status
-	}
+	return Running
+}
 
-	// All children are done but we don't have enough successes
-	p.status = Failure
-	return p.status
+// haltRunning halts the children that are still Running once the outcome of a parallel node is decided.
+func haltRunning(children []Node, statuses []Status) {
+	for i, status := range statuses {
+		if status == Running {
+			HaltNode(children[i])
+		}
+	}
 }
 
 // Status returns the current status of the Parallel node.
@@ -570,10 +1320,18 @@ func (p *Parallel) Status() Status {
 //   - A string that represents the Parallel node, including its current status, MinSuccessCount, and all child nodes.
 func (p *Parallel) String() string {
 	var builder strings.Builder
-	builder.WriteString("Parallel (")
+	builder.WriteString(nodeLabel("Parallel", p.Name, p.path) + " (")
 	builder.WriteString(p.Status().String())
+	if p.Policy != ParallelThreshold {
+		builder.WriteString(", Policy: ")
+		builder.WriteString(p.Policy.String())
+	}
 	builder.WriteString(", MinSuccess: ")
 	builder.WriteString(strconv.Itoa(p.MinSuccessCount))
+	if p.MinFailureCount > 0 {
+		builder.WriteString(", MinFailure: ")
+		builder.WriteString(strconv.Itoa(p.MinFailureCount))
+	}
 	builder.WriteString(")")
 	for _, child := range p.Children {
 		str := child.String()
@@ -586,26 +1344,101 @@ func (p *Parallel) String() string {
 	return builder.String()
 }
 
-// Retry represents a decorator node that retries its child until it succeeds,
-// ignoring all failures. It returns Success when the child succeeds, Running
-// while the child is running, and keeps retrying (returning Running) when the
-// child fails.
+// NodeName returns the optional name of the Parallel node.
+//
+// Returns:
+//   - The Name of the node, or the empty string if it has none.
+func (p *Parallel) NodeName() string {
+	return p.Name
+}
+
+// Path returns the path of the Parallel node in its tree, such as "root/0/2".
+//
+// Returns:
+//   - The path assigned by AssignPaths, or the empty string if none has been assigned.
+func (p *Parallel) Path() string {
+	return p.path
+}
+
+// SetPath sets the path of the Parallel node in its tree. It is called by AssignPaths.
+//
+// Parameters:
+//   - path: The path of the node.
+func (p *Parallel) SetPath(path string) {
+	p.path = path
+}
+
+// ChildNodes returns the children of the Parallel node.
+//
+// Returns:
+//   - The Children of the node, in order.
+func (p *Parallel) ChildNodes() []Node {
+	return p.Children
+}
+
+// Retry represents a decorator node that retries its child until it succeeds.
+// It returns Success when the child succeeds, Running while the child is running,
+// and keeps retrying (returning Running) when the child fails. By default it retries
+// forever on the next tick; MaxAttempts bounds the number of attempts, Backoff delays
+// each retry without blocking the tree, and Retryable decides which failures are retried.
 type Retry struct {
-	Child  Node
-	status Status
+	Name        string // Optional name that identifies the node in String(), logs and tooling
+	Child       Node
+	MaxAttempts int                                         // Maximum number of attempts. If zero or negative, retries forever
+	Backoff     Backoff                                     // Optional delay before each retry. If nil, retries on the next tick
+	Retryable   func(ctx context.Context, attempt int) bool // Optional predicate deciding whether a failed attempt is retried
+	Clock       Clock                                       // Optional clock used for the backoff delay. If nil, the tree's clock is used
+	status      Status
+	attempts    int       // Number of attempts that have failed
+	gaveUp      bool      // Whether the attempts are exhausted or a failure was not retryable
+	nextAttempt time.Time // Time before which the child is not retried
+	path        string    // Path of the node in its tree, assigned by AssignPaths
 }
 
-// Tick executes the Retry node, running its child until it succeeds.
+// Tick executes the Retry node with a background context.
 //
 // Returns:
-//   - The status of the Retry node after execution, which can be Ready, Running, Success, or Failure.
+//   - The status of the Retry node after execution. See TickContext for details.
 func (r *Retry) Tick() Status {
+	return r.TickContext(context.Background())
+}
+
+// TickContext executes the Retry node, running its child until it succeeds or the attempts are exhausted.
+//
+// Parameters:
+//   - ctx: The context for this tick. It is passed to the children of the node.
+//     If ctx is cancelled, the node fails without ticking its children.
+//
+// Returns:
+//   - The status of the Retry node after execution, which can be Ready, Running, Success, or Failure.
+//     The node returns Running while the child is running or a retry is pending (including while waiting
+//     for the backoff delay), and Failure once MaxAttempts attempts have failed or a failure is not retryable.
+func (r *Retry) TickContext(ctx context.Context) Status {
+	if ctx.Err() != nil {
+		r.status = Failure
+		return r.status
+	}
+
 	if r.Child == nil {
 		r.status = Failure
 		return r.status
 	}
 
-	childStatus := r.Child.Tick()
+	// We've already given up, return the stored result until the node is reset
+	if r.gaveUp {
+		r.status = Failure
+		return r.status
+	}
+
+	// Wait for the backoff delay to expire before the next attempt
+	clock := nodeClock(ctx, r.Clock)
+	if !r.nextAttempt.IsZero() && clock.Now().Before(r.nextAttempt) {
+		r.status = Running
+		return r.status
+	}
+	r.nextAttempt = time.Time{}
+
+	childStatus := TickNode(ctx, r.Child)
 	switch childStatus {
 	case Success:
 		r.status = Success
@@ -614,8 +1447,22 @@ func (r *Retry) Tick() Status {
 		r.status = Running
 		return r.status
 	case Failure:
-		// Ignore failure, reset child and keep trying
+		r.attempts++
+		if r.MaxAttempts > 0 && r.attempts >= r.MaxAttempts {
+			r.gaveUp = true // Out of attempts
+			r.status = Failure
+			return r.status
+		}
+		if r.Retryable != nil && !r.Retryable(ctx, r.attempts) {
+			r.gaveUp = true // This failure should not be retried
+			r.status = Failure
+			return r.status
+		}
+		// Reset child and try again, after the backoff delay if there is one
 		r.Child.Reset()
+		if r.Backoff != nil {
+			r.nextAttempt = clock.Now().Add(r.Backoff.Delay(r.attempts))
+		}
 		r.status = Running
 		return r.status
 	default:
@@ -624,6 +1471,15 @@ func (r *Retry) Tick() Status {
 	}
 }
 
+// Attempts returns the number of attempts of the child node that have failed since the Retry node
+// was last reset.
+//
+// Returns:
+//   - The number of failed attempts.
+func (r *Retry) Attempts() int {
+	return r.attempts
+}
+
 // Reset resets the Retry node and its child to the Ready state.
 //
 // Returns:
@@ -631,12 +1487,31 @@ func (r *Retry) Tick() Status {
 //     to its initial state.
 func (r *Retry) Reset() Status {
 	r.status = Ready
+	r.attempts = 0
+	r.gaveUp = false
+	r.nextAttempt = time.Time{}
 	if r.Child != nil {
 		r.Child.Reset()
 	}
 	return r.status
 }
 
+// Halt interrupts the Retry node and its child, returning both to the Ready state.
+//
+// Returns:
+//   - The status of the Retry node after halting, which will be Ready. The child node is halted if it is
+//     Running and reset otherwise.
+func (r *Retry) Halt() Status {
+	r.status = Ready
+	r.attempts = 0
+	r.gaveUp = false
+	r.nextAttempt = time.Time{}
+	if r.Child != nil {
+		HaltNode(r.Child)
+	}
+	return r.status
+}
+
 // Status returns the current status of the Retry node.
 //
 // Returns:
@@ -648,11 +1523,18 @@ func (r *Retry) Status() Status {
 // String returns a string representation of the Retry node.
 //
 // Returns:
-//   - A string that represents the Retry node, including its current status and the child node (if it exists).
+//   - A string that represents the Retry node, including its current status, the number of failed attempts,
+//     the maximum number of attempts (if bounded), and the child node (if it exists).
 func (r *Retry) String() string {
 	var builder strings.Builder
-	builder.WriteString("Retry (")
+	builder.WriteString(nodeLabel("Retry", r.Name, r.path) + " (")
 	builder.WriteString(r.Status().String())
+	builder.WriteString(", Attempts: ")
+	builder.WriteString(strconv.Itoa(r.attempts))
+	if r.MaxAttempts > 0 {
+		builder.WriteString("/")
+		builder.WriteString(strconv.Itoa(r.MaxAttempts))
+	}
 	builder.WriteString(")")
 	if r.Child != nil {
 		builder.WriteString("\n  ")
@@ -666,25 +1548,76 @@ func (r *Retry) String() string {
 	return builder.String()
 }
 
+// NodeName returns the optional name of the Retry node.
+//
+// Returns:
+//   - The Name of the node, or the empty string if it has none.
+func (r *Retry) NodeName() string {
+	return r.Name
+}
+
+// Path returns the path of the Retry node in its tree, such as "root/0/2".
+//
+// Returns:
+//   - The path assigned by AssignPaths, or the empty string if none has been assigned.
+func (r *Retry) Path() string {
+	return r.path
+}
+
+// SetPath sets the path of the Retry node in its tree. It is called by AssignPaths.
+//
+// Parameters:
+//   - path: The path of the node.
+func (r *Retry) SetPath(path string) {
+	r.path = path
+}
+
+// ChildNodes returns the child of the Retry node.
+//
+// Returns:
+//   - A slice holding the Child of the node, or an empty slice if it is not set.
+func (r *Retry) ChildNodes() []Node {
+	return childSlice(r.Child)
+}
+
 // Repeat represents a decorator node that repeats its child until it fails.
 // It returns Running while the child succeeds (and resets it for the next iteration),
 // Running while the child is running, and Failure when the child fails.
 type Repeat struct {
+	Name   string // Optional name that identifies the node in String(), logs and tooling
 	Child  Node
 	status Status
+	path   string // Path of the node in its tree, assigned by AssignPaths
 }
 
-// Tick executes the Repeat node, running its child repeatedly until it fails.
+// Tick executes the Repeat node with a background context.
 //
 // Returns:
-//   - The status of the Repeat node after execution, which can be Ready, Running, Success, or Failure.
+//   - The status of the Repeat node after execution. See TickContext for details.
 func (rp *Repeat) Tick() Status {
+	return rp.TickContext(context.Background())
+}
+
+// TickContext executes the Repeat node, running its child repeatedly until it fails.
+//
+// Parameters:
+//   - ctx: The context for this tick. It is passed to the children of the node.
+//     If ctx is cancelled, the node fails without ticking its children.
+//
+// Returns:
+//   - The status of the Repeat node after execution, which can be Ready, Running, Success, or Failure.
+func (rp *Repeat) TickContext(ctx context.Context) Status {
+	if ctx.Err() != nil {
+		rp.status = Failure
+		return rp.status
+	}
+
 	if rp.Child == nil {
 		rp.status = Failure
 		return rp.status
 	}
 
-	childStatus := rp.Child.Tick()
+	childStatus := TickNode(ctx, rp.Child)
 	switch childStatus {
 	case Success:
 		// Child succeeded, reset it and continue repeating
@@ -717,6 +1650,19 @@ func (rp *Repeat) Reset() Status {
 	return rp.status
 }
 
+// Halt interrupts the Repeat node and its child, returning both to the Ready state.
+//
+// Returns:
+//   - The status of the Repeat node after halting, which will be Ready. The child node is halted if it is
+//     Running and reset otherwise.
+func (rp *Repeat) Halt() Status {
+	rp.status = Ready
+	if rp.Child != nil {
+		HaltNode(rp.Child)
+	}
+	return rp.status
+}
+
 // Status returns the current status of the Repeat node.
 //
 // Returns:
@@ -731,7 +1677,7 @@ func (rp *Repeat) Status() Status {
 //   - A string that represents the Repeat node, including its current status and the child node (if it exists).
 func (rp *Repeat) String() string {
 	var builder strings.Builder
-	builder.WriteString("Repeat (")
+	builder.WriteString(nodeLabel("Repeat", rp.Name, rp.path) + " (")
 	builder.WriteString(rp.Status().String())
 	builder.WriteString(")")
 	if rp.Child != nil {
@@ -746,24 +1692,75 @@ func (rp *Repeat) String() string {
 	return builder.String()
 }
 
+// NodeName returns the optional name of the Repeat node.
+//
+// Returns:
+//   - The Name of the node, or the empty string if it has none.
+func (rp *Repeat) NodeName() string {
+	return rp.Name
+}
+
+// Path returns the path of the Repeat node in its tree, such as "root/0/2".
+//
+// Returns:
+//   - The path assigned by AssignPaths, or the empty string if none has been assigned.
+func (rp *Repeat) Path() string {
+	return rp.path
+}
+
+// SetPath sets the path of the Repeat node in its tree. It is called by AssignPaths.
+//
+// Parameters:
+//   - path: The path of the node.
+func (rp *Repeat) SetPath(path string) {
+	rp.path = path
+}
+
+// ChildNodes returns the child of the Repeat node.
+//
+// Returns:
+//   - A slice holding the Child of the node, or an empty slice if it is not set.
+func (rp *Repeat) ChildNodes() []Node {
+	return childSlice(rp.Child)
+}
+
 // Invert represents a decorator node that inverts the result of its child.
 // It changes Success to Failure and Failure to Success. Running and Ready states pass through unchanged.
 type Invert struct {
+	Name   string // Optional name that identifies the node in String(), logs and tooling
 	Child  Node
 	status Status
+	path   string // Path of the node in its tree, assigned by AssignPaths
 }
 
-// Tick executes the Invert node, running its child and inverting Success/Failure results.
+// Tick executes the Invert node with a background context.
 //
 // Returns:
-//   - The status of the Invert node after execution, which can be Ready, Running, Success, or Failure.
+//   - The status of the Invert node after execution. See TickContext for details.
 func (i *Invert) Tick() Status {
+	return i.TickContext(context.Background())
+}
+
+// TickContext executes the Invert node, running its child and inverting Success/Failure results.
+//
+// Parameters:
+//   - ctx: The context for this tick. It is passed to the children of the node.
+//     If ctx is cancelled, the node fails without ticking its children.
+//
+// Returns:
+//   - The status of the Invert node after execution, which can be Ready, Running, Success, or Failure.
+func (i *Invert) TickContext(ctx context.Context) Status {
+	if ctx.Err() != nil {
+		i.status = Failure
+		return i.status
+	}
+
 	if i.Child == nil {
 		i.status = Failure
 		return i.status
 	}
 
-	childStatus := i.Child.Tick()
+	childStatus := TickNode(ctx, i.Child)
 	switch childStatus {
 	case Success:
 		i.status = Failure
@@ -796,6 +1793,19 @@ func (i *Invert) Reset() Status {
 	return i.status
 }
 
+// Halt interrupts the Invert node and its child, returning both to the Ready state.
+//
+// Returns:
+//   - The status of the Invert node after halting, which will be Ready. The child node is halted if it is
+//     Running and reset otherwise.
+func (i *Invert) Halt() Status {
+	i.status = Ready
+	if i.Child != nil {
+		HaltNode(i.Child)
+	}
+	return i.status
+}
+
 // Status returns the current status of the Invert node.
 //
 // Returns:
@@ -810,7 +1820,7 @@ func (i *Invert) Status() Status {
 //   - A string that represents the Invert node, including its current status and the child node (if it exists).
 func (i *Invert) String() string {
 	var builder strings.Builder
-	builder.WriteString("Invert (")
+	builder.WriteString(nodeLabel("Invert", i.Name, i.path) + " (")
 	builder.WriteString(i.Status().String())
 	builder.WriteString(")")
 	if i.Child != nil {
@@ -824,25 +1834,76 @@ func (i *Invert) String() string {
 	return builder.String()
 }
 
+// NodeName returns the optional name of the Invert node.
+//
+// Returns:
+//   - The Name of the node, or the empty string if it has none.
+func (i *Invert) NodeName() string {
+	return i.Name
+}
+
+// Path returns the path of the Invert node in its tree, such as "root/0/2".
+//
+// Returns:
+//   - The path assigned by AssignPaths, or the empty string if none has been assigned.
+func (i *Invert) Path() string {
+	return i.path
+}
+
+// SetPath sets the path of the Invert node in its tree. It is called by AssignPaths.
+//
+// Parameters:
+//   - path: The path of the node.
+func (i *Invert) SetPath(path string) {
+	i.path = path
+}
+
+// ChildNodes returns the child of the Invert node.
+//
+// Returns:
+//   - A slice holding the Child of the node, or an empty slice if it is not set.
+func (i *Invert) ChildNodes() []Node {
+	return childSlice(i.Child)
+}
+
 // AlwaysSuccess represents a decorator node that always returns Success even if the child fails.
 // This is useful for ensuring certain branches always appear successful to their parent nodes.
 type AlwaysSuccess struct {
+	Name   string // Optional name that identifies the node in String(), logs and tooling
 	Child  Node
 	status Status
+	path   string // Path of the node in its tree, assigned by AssignPaths
 }
 
-// Tick executes the AlwaysSuccess node, running its child but returning Success even if the child fails.
+// Tick executes the AlwaysSuccess node with a background context.
 //
 // Returns:
-//   - The status of the AlwaysSuccess node after execution, which can be Ready, Running, Success, or Failure.
+//   - The status of the AlwaysSuccess node after execution. See TickContext for details.
 func (as *AlwaysSuccess) Tick() Status {
+	return as.TickContext(context.Background())
+}
+
+// TickContext executes the AlwaysSuccess node, running its child but returning Success even if the child fails.
+//
+// Parameters:
+//   - ctx: The context for this tick. It is passed to the children of the node.
+//     If ctx is cancelled, the node fails without ticking its children.
+//
+// Returns:
+//   - The status of the AlwaysSuccess node after execution, which can be Ready, Running, Success, or Failure.
+func (as *AlwaysSuccess) TickContext(ctx context.Context) Status {
+	if ctx.Err() != nil {
+		as.status = Failure
+		return as.status
+	}
+
 	if as.Child == nil {
 		as.status = Success
 		return as.status
 	}
 
 	// Execute the child but ignore its result
-	as.status = as.Child.Tick()
+	as.status = TickNode(ctx, as.Child)
 
 	// Return Success even if the child failed
 	if as.status == Failure {
@@ -865,6 +1926,19 @@ func (as *AlwaysSuccess) Reset() Status {
 	return as.status
 }
 
+// Halt interrupts the AlwaysSuccess node and its child, returning both to the Ready state.
+//
+// Returns:
+//   - The status of the AlwaysSuccess node after halting, which will be Ready. The child node is halted if it is
+//     Running and reset otherwise.
+func (as *AlwaysSuccess) Halt() Status {
+	as.status = Ready
+	if as.Child != nil {
+		HaltNode(as.Child)
+	}
+	return as.status
+}
+
 // Status returns the current status of the AlwaysSuccess node.
 //
 // Returns:
@@ -881,7 +1955,7 @@ func (as *AlwaysSuccess) Status() Status {
 //   - A string that represents the AlwaysSuccess node, including its current status and the child node (if it exists).
 func (as *AlwaysSuccess) String() string {
 	var builder strings.Builder
-	builder.WriteString("AlwaysSuccess (")
+	builder.WriteString(nodeLabel("AlwaysSuccess", as.Name, as.path) + " (")
 	builder.WriteString(as.Status().String())
 	builder.WriteString(")")
 	if as.Child != nil {
@@ -895,25 +1969,76 @@ func (as *AlwaysSuccess) String() string {
 	return builder.String()
 }
 
+// NodeName returns the optional name of the AlwaysSuccess node.
+//
+// Returns:
+//   - The Name of the node, or the empty string if it has none.
+func (as *AlwaysSuccess) NodeName() string {
+	return as.Name
+}
+
+// Path returns the path of the AlwaysSuccess node in its tree, such as "root/0/2".
+//
+// Returns:
+//   - The path assigned by AssignPaths, or the empty string if none has been assigned.
+func (as *AlwaysSuccess) Path() string {
+	return as.path
+}
+
+// SetPath sets the path of the AlwaysSuccess node in its tree. It is called by AssignPaths.
+//
+// Parameters:
+//   - path: The path of the node.
+func (as *AlwaysSuccess) SetPath(path string) {
+	as.path = path
+}
+
+// ChildNodes returns the child of the AlwaysSuccess node.
+//
+// Returns:
+//   - A slice holding the Child of the node, or an empty slice if it is not set.
+func (as *AlwaysSuccess) ChildNodes() []Node {
+	return childSlice(as.Child)
+}
+
 // AlwaysFailure represents a decorator node that always returns Failure even if the child succeeds.
 // This is useful for testing or ensuring certain branches always appear failed to their parent nodes.
 type AlwaysFailure struct {
+	Name   string // Optional name that identifies the node in String(), logs and tooling
 	Child  Node
 	status Status
+	path   string // Path of the node in its tree, assigned by AssignPaths
 }
 
-// Tick executes the AlwaysFailure node, running its child but returning Failure even if the child succeeds.
+// Tick executes the AlwaysFailure node with a background context.
 //
 // Returns:
-//   - The status of the AlwaysFailure node after execution, which can be Ready, Running, Success, or Failure.
+//   - The status of the AlwaysFailure node after execution. See TickContext for details.
 func (af *AlwaysFailure) Tick() Status {
+	return af.TickContext(context.Background())
+}
+
+// TickContext executes the AlwaysFailure node, running its child but returning Failure even if the child succeeds.
+//
+// Parameters:
+//   - ctx: The context for this tick. It is passed to the children of the node.
+//     If ctx is cancelled, the node fails without ticking its children.
+//
+// Returns:
+//   - The status of the AlwaysFailure node after execution, which can be Ready, Running, Success, or Failure.
+func (af *AlwaysFailure) TickContext(ctx context.Context) Status {
+	if ctx.Err() != nil {
+		af.status = Failure
+		return af.status
+	}
+
 	if af.Child == nil {
 		af.status = Failure
 		return af.status
 	}
 
 	// Execute the child but ignore its result
-	af.status = af.Child.Tick()
+	af.status = TickNode(ctx, af.Child)
 
 	// Return Failure even if the child succeeded
 	if af.status == Success {
@@ -936,6 +2061,19 @@ func (af *AlwaysFailure) Reset() Status {
 	return af.status
 }
 
+// Halt interrupts the AlwaysFailure node and its child, returning both to the Ready state.
+//
+// Returns:
+//   - The status of the AlwaysFailure node after halting, which will be Ready. The child node is halted if it is
+//     Running and reset otherwise.
+func (af *AlwaysFailure) Halt() Status {
+	af.status = Ready
+	if af.Child != nil {
+		HaltNode(af.Child)
+	}
+	return af.status
+}
+
 // Status returns the current status of the AlwaysFailure node.
 //
 // Returns:
@@ -952,7 +2090,7 @@ func (af *AlwaysFailure) Status() Status {
 //   - A string that represents the AlwaysFailure node, including its current status and the child node (if it exists).
 func (af *AlwaysFailure) String() string {
 	var builder strings.Builder
-	builder.WriteString("AlwaysFailure (")
+	builder.WriteString(nodeLabel("AlwaysFailure", af.Name, af.path) + " (")
 	builder.WriteString(af.Status().String())
 	builder.WriteString(")")
 	if af.Child != nil {
@@ -966,21 +2104,72 @@ func (af *AlwaysFailure) String() string {
 	return builder.String()
 }
 
+// NodeName returns the optional name of the AlwaysFailure node.
+//
+// Returns:
+//   - The Name of the node, or the empty string if it has none.
+func (af *AlwaysFailure) NodeName() string {
+	return af.Name
+}
+
+// Path returns the path of the AlwaysFailure node in its tree, such as "root/0/2".
+//
+// Returns:
+//   - The path assigned by AssignPaths, or the empty string if none has been assigned.
+func (af *AlwaysFailure) Path() string {
+	return af.path
+}
+
+// SetPath sets the path of the AlwaysFailure node in its tree. It is called by AssignPaths.
+//
+// Parameters:
+//   - path: The path of the node.
+func (af *AlwaysFailure) SetPath(path string) {
+	af.path = path
+}
+
+// ChildNodes returns the child of the AlwaysFailure node.
+//
+// Returns:
+//   - A slice holding the Child of the node, or an empty slice if it is not set.
+func (af *AlwaysFailure) ChildNodes() []Node {
+	return childSlice(af.Child)
+}
+
 // RepeatN represents a decorator node that executes its child a specific number of times.
 // It returns Running while the execution count is below MaxCount, then returns the child's last result.
 type RepeatN struct {
+	Name     string // Optional name that identifies the node in String(), logs and tooling
 	Child    Node
 	MaxCount int // Maximum number of times to execute the child
 	Count    int // Current execution count
 	status   Status
+	path     string // Path of the node in its tree, assigned by AssignPaths
+}
+
+// Tick executes the RepeatN node with a background context.
+//
+// Returns:
+//   - The status of the RepeatN node after execution. See TickContext for details.
+func (rn *RepeatN) Tick() Status {
+	return rn.TickContext(context.Background())
 }
 
-// Tick executes the RepeatN node, running its child up to MaxCount times.
+// TickContext executes the RepeatN node, running its child up to MaxCount times.
+//
+// Parameters:
+//   - ctx: The context for this tick. It is passed to the children of the node.
+//     If ctx is cancelled, the node fails without ticking its children.
 //
 // Returns:
 //   - The status of the RepeatN node after execution, which can be Ready, Running, Success, or Failure.
 //     The node returns Running while the execution count is below MaxCount, and returns the child's last result once MaxCount is reached.
-func (rn *RepeatN) Tick() Status {
+func (rn *RepeatN) TickContext(ctx context.Context) Status {
+	if ctx.Err() != nil {
+		rn.status = Failure
+		return rn.status
+	}
+
 	if rn.Child == nil {
 		rn.status = Failure
 		rn.Count = rn.MaxCount // Set count to MaxCount when there's no child
@@ -990,7 +2179,7 @@ func (rn *RepeatN) Tick() Status {
 	// If we haven't reached the maximum count yet
 	if rn.MaxCount <= 0 || rn.Count < rn.MaxCount {
 		// Execute the child
-		childStatus := rn.Child.Tick()
+		childStatus := TickNode(ctx, rn.Child)
 
 		// If child is still running, don't increment count yet
 		if childStatus == Running {
@@ -1031,6 +2220,20 @@ func (rn *RepeatN) Reset() Status {
 	return rn.status
 }
 
+// Halt interrupts the RepeatN node and its child, returning both to the Ready state.
+//
+// Returns:
+//   - The status of the RepeatN node after halting, which will be Ready. The child node is halted if it is
+//     Running and reset otherwise.
+func (rn *RepeatN) Halt() Status {
+	rn.status = Ready
+	rn.Count = 0
+	if rn.Child != nil {
+		HaltNode(rn.Child)
+	}
+	return rn.status
+}
+
 // Status returns the current status of the RepeatN node.
 //
 // Returns:
@@ -1046,7 +2249,7 @@ func (rn *RepeatN) Status() Status {
 //     and the child node (if it exists).
 func (rn *RepeatN) String() string {
 	var builder strings.Builder
-	builder.WriteString("RepeatN (")
+	builder.WriteString(nodeLabel("RepeatN", rn.Name, rn.path) + " (")
 	builder.WriteString(rn.Status().String())
 	builder.WriteString(", ")
 	builder.WriteString(strconv.Itoa(rn.Count))
@@ -1064,20 +2267,71 @@ func (rn *RepeatN) String() string {
 	return builder.String()
 }
 
+// NodeName returns the optional name of the RepeatN node.
+//
+// Returns:
+//   - The Name of the node, or the empty string if it has none.
+func (rn *RepeatN) NodeName() string {
+	return rn.Name
+}
+
+// Path returns the path of the RepeatN node in its tree, such as "root/0/2".
+//
+// Returns:
+//   - The path assigned by AssignPaths, or the empty string if none has been assigned.
+func (rn *RepeatN) Path() string {
+	return rn.path
+}
+
+// SetPath sets the path of the RepeatN node in its tree. It is called by AssignPaths.
+//
+// Parameters:
+//   - path: The path of the node.
+func (rn *RepeatN) SetPath(path string) {
+	rn.path = path
+}
+
+// ChildNodes returns the child of the RepeatN node.
+//
+// Returns:
+//   - A slice holding the Child of the node, or an empty slice if it is not set.
+func (rn *RepeatN) ChildNodes() []Node {
+	return childSlice(rn.Child)
+}
+
 // Forever represents a decorator node that runs its child forever, ignoring its status.
 type Forever struct {
+	Name   string // Optional name that identifies the node in String(), logs and tooling
 	Child  Node
 	status Status
+	path   string // Path of the node in its tree, assigned by AssignPaths
+}
+
+// Tick executes the Forever node with a background context.
+//
+// Returns:
+//   - The status of the Forever node after execution. See TickContext for details.
+func (f *Forever) Tick() Status {
+	return f.TickContext(context.Background())
 }
 
-// Tick executes the Forever node, always returning Running regardless of the child's status.
+// TickContext executes the Forever node, always returning Running regardless of the child's status.
+//
+// Parameters:
+//   - ctx: The context for this tick. It is passed to the children of the node.
+//     If ctx is cancelled, the node fails without ticking its children.
 //
 // Returns:
 //   - The status of the Forever node after execution, which will always be Running. This node ignores
 //     the child's status and continues running indefinitely.
-func (f *Forever) Tick() Status {
+func (f *Forever) TickContext(ctx context.Context) Status {
+	if ctx.Err() != nil {
+		f.status = Failure
+		return f.status
+	}
+
 	if f.Child != nil {
-		f.Child.Tick()
+		TickNode(ctx, f.Child)
 	}
 	f.status = Running
 	return Running
@@ -1096,6 +2350,19 @@ func (f *Forever) Reset() Status {
 	return f.status
 }
 
+// Halt interrupts the Forever node and its child, returning both to the Ready state.
+//
+// Returns:
+//   - The status of the Forever node after halting, which will be Ready. The child node is halted if it is
+//     Running and reset otherwise.
+func (f *Forever) Halt() Status {
+	f.status = Ready
+	if f.Child != nil {
+		HaltNode(f.Child)
+	}
+	return f.status
+}
+
 // Status returns the current status of the Forever node.
 //
 // Returns:
@@ -1111,7 +2378,7 @@ func (f *Forever) Status() Status {
 //   - A string that represents the Forever node, including its current status and the child node (if it exists).
 func (f *Forever) String() string {
 	var builder strings.Builder
-	builder.WriteString("Forever (")
+	builder.WriteString(nodeLabel("Forever", f.Name, f.path) + " (")
 	builder.WriteString(f.status.String())
 	builder.WriteString(")")
 	if f.Child != nil {
@@ -1125,27 +2392,78 @@ func (f *Forever) String() string {
 	return builder.String()
 }
 
+// NodeName returns the optional name of the Forever node.
+//
+// Returns:
+//   - The Name of the node, or the empty string if it has none.
+func (f *Forever) NodeName() string {
+	return f.Name
+}
+
+// Path returns the path of the Forever node in its tree, such as "root/0/2".
+//
+// Returns:
+//   - The path assigned by AssignPaths, or the empty string if none has been assigned.
+func (f *Forever) Path() string {
+	return f.path
+}
+
+// SetPath sets the path of the Forever node in its tree. It is called by AssignPaths.
+//
+// Parameters:
+//   - path: The path of the node.
+func (f *Forever) SetPath(path string) {
+	f.path = path
+}
+
+// ChildNodes returns the child of the Forever node.
+//
+// Returns:
+//   - A slice holding the Child of the node, or an empty slice if it is not set.
+func (f *Forever) ChildNodes() []Node {
+	return childSlice(f.Child)
+}
+
 // WhileSuccess represents a decorator node that returns Running as long as its child
 // is either Running or Success, and returns Failure otherwise.
 // This is useful for creating loops that continue while a condition remains true.
 type WhileSuccess struct {
+	Name   string // Optional name that identifies the node in String(), logs and tooling
 	Child  Node
 	status Status
+	path   string // Path of the node in its tree, assigned by AssignPaths
 }
 
-// Tick executes the WhileSuccess node, running its child and continuing while it succeeds or runs.
+// Tick executes the WhileSuccess node with a background context.
+//
+// Returns:
+//   - The status of the WhileSuccess node after execution. See TickContext for details.
+func (ws *WhileSuccess) Tick() Status {
+	return ws.TickContext(context.Background())
+}
+
+// TickContext executes the WhileSuccess node, running its child and continuing while it succeeds or runs.
+//
+// Parameters:
+//   - ctx: The context for this tick. It is passed to the children of the node.
+//     If ctx is cancelled, the node fails without ticking its children.
 //
 // Returns:
 //   - The status of the WhileSuccess node after execution, which can be Ready, Running, Success, or Failure.
 //     The node returns Running while the child is Running or Success, and returns Failure if the child fails or is not ready.
-func (ws *WhileSuccess) Tick() Status {
+func (ws *WhileSuccess) TickContext(ctx context.Context) Status {
+	if ctx.Err() != nil {
+		ws.status = Failure
+		return ws.status
+	}
+
 	if ws.Child == nil {
 		ws.status = Failure
 		return ws.status
 	}
 
 	// Execute the child
-	childStatus := ws.Child.Tick()
+	childStatus := TickNode(ctx, ws.Child)
 
 	// Continue running if child is Running or Success
 	if childStatus == Running || childStatus == Success {
@@ -1175,6 +2493,19 @@ func (ws *WhileSuccess) Reset() Status {
 	return ws.status
 }
 
+// Halt interrupts the WhileSuccess node and its child, returning both to the Ready state.
+//
+// Returns:
+//   - The status of the WhileSuccess node after halting, which will be Ready. The child node is halted if it is
+//     Running and reset otherwise.
+func (ws *WhileSuccess) Halt() Status {
+	ws.status = Ready
+	if ws.Child != nil {
+		HaltNode(ws.Child)
+	}
+	return ws.status
+}
+
 // Status returns the current status of the WhileSuccess node.
 //
 // Returns:
@@ -1191,7 +2522,7 @@ func (ws *WhileSuccess) Status() Status {
 //   - A string that represents the WhileSuccess node, including its current status and the child node (if it exists).
 func (ws *WhileSuccess) String() string {
 	var builder strings.Builder
-	builder.WriteString("WhileSuccess (")
+	builder.WriteString(nodeLabel("WhileSuccess", ws.Name, ws.path) + " (")
 	builder.WriteString(ws.status.String())
 	builder.WriteString(")")
 	if ws.Child != nil {
@@ -1205,26 +2536,77 @@ func (ws *WhileSuccess) String() string {
 	return builder.String()
 }
 
+// NodeName returns the optional name of the WhileSuccess node.
+//
+// Returns:
+//   - The Name of the node, or the empty string if it has none.
+func (ws *WhileSuccess) NodeName() string {
+	return ws.Name
+}
+
+// Path returns the path of the WhileSuccess node in its tree, such as "root/0/2".
+//
+// Returns:
+//   - The path assigned by AssignPaths, or the empty string if none has been assigned.
+func (ws *WhileSuccess) Path() string {
+	return ws.path
+}
+
+// SetPath sets the path of the WhileSuccess node in its tree. It is called by AssignPaths.
+//
+// Parameters:
+//   - path: The path of the node.
+func (ws *WhileSuccess) SetPath(path string) {
+	ws.path = path
+}
+
+// ChildNodes returns the child of the WhileSuccess node.
+//
+// Returns:
+//   - A slice holding the Child of the node, or an empty slice if it is not set.
+func (ws *WhileSuccess) ChildNodes() []Node {
+	return childSlice(ws.Child)
+}
+
 // WhileFailure represents a decorator node that returns Running as long as its child
 // returns Running or Failure, and returns Success when the child succeeds.
 // It continues executing its child while it fails or is running.
 type WhileFailure struct {
+	Name   string // Optional name that identifies the node in String(), logs and tooling
 	Child  Node
 	status Status
+	path   string // Path of the node in its tree, assigned by AssignPaths
+}
+
+// Tick executes the WhileFailure node with a background context.
+//
+// Returns:
+//   - The status of the WhileFailure node after execution. See TickContext for details.
+func (wf *WhileFailure) Tick() Status {
+	return wf.TickContext(context.Background())
 }
 
-// Tick executes the WhileFailure node, running its child and continuing while it fails or runs.
+// TickContext executes the WhileFailure node, running its child and continuing while it fails or runs.
+//
+// Parameters:
+//   - ctx: The context for this tick. It is passed to the children of the node.
+//     If ctx is cancelled, the node fails without ticking its children.
 //
 // Returns:
 //   - The status of the WhileFailure node after execution, which can be Ready, Running, Success, or Failure.
 //     The node returns Running while the child is Running or Failure, and returns Success if the child succeeds.
-func (wf *WhileFailure) Tick() Status {
+func (wf *WhileFailure) TickContext(ctx context.Context) Status {
+	if ctx.Err() != nil {
+		wf.status = Failure
+		return wf.status
+	}
+
 	if wf.Child == nil {
 		wf.status = Success // No child means we're done (child "succeeded")
 		return wf.status
 	}
 
-	childStatus := wf.Child.Tick()
+	childStatus := TickNode(ctx, wf.Child)
 
 	switch childStatus {
 	case Running, Failure:
@@ -1259,6 +2641,19 @@ func (wf *WhileFailure) Reset() Status {
 	return wf.status
 }
 
+// Halt interrupts the WhileFailure node and its child, returning both to the Ready state.
+//
+// Returns:
+//   - The status of the WhileFailure node after halting, which will be Ready. The child node is halted if it is
+//     Running and reset otherwise.
+func (wf *WhileFailure) Halt() Status {
+	wf.status = Ready
+	if wf.Child != nil {
+		HaltNode(wf.Child)
+	}
+	return wf.status
+}
+
 // Status returns the current status of the WhileFailure node.
 //
 // Returns:
@@ -1275,7 +2670,7 @@ func (wf *WhileFailure) Status() Status {
 //   - A string that represents the WhileFailure node, including its current status and the child node (if it exists).
 func (wf *WhileFailure) String() string {
 	var builder strings.Builder
-	builder.WriteString("WhileFailure (")
+	builder.WriteString(nodeLabel("WhileFailure", wf.Name, wf.path) + " (")
 	builder.WriteString(wf.status.String())
 	builder.WriteString(")")
 	if wf.Child != nil {
@@ -1290,42 +2685,96 @@ func (wf *WhileFailure) String() string {
 	return builder.String()
 }
 
+// NodeName returns the optional name of the WhileFailure node.
+//
+// Returns:
+//   - The Name of the node, or the empty string if it has none.
+func (wf *WhileFailure) NodeName() string {
+	return wf.Name
+}
+
+// Path returns the path of the WhileFailure node in its tree, such as "root/0/2".
+//
+// Returns:
+//   - The path assigned by AssignPaths, or the empty string if none has been assigned.
+func (wf *WhileFailure) Path() string {
+	return wf.path
+}
+
+// SetPath sets the path of the WhileFailure node in its tree. It is called by AssignPaths.
+//
+// Parameters:
+//   - path: The path of the node.
+func (wf *WhileFailure) SetPath(path string) {
+	wf.path = path
+}
+
+// ChildNodes returns the child of the WhileFailure node.
+//
+// Returns:
+//   - A slice holding the Child of the node, or an empty slice if it is not set.
+func (wf *WhileFailure) ChildNodes() []Node {
+	return childSlice(wf.Child)
+}
+
 // WithTimeout represents a decorator node that runs its child for a maximum duration.
 // If the child returns Success or Failure before the duration expires, it returns that status.
-// If the duration expires while the child is still Running, it returns Failure.
+// If the duration expires while the child is still Running, the child is halted and it returns Failure.
 type WithTimeout struct {
+	Name      string // Optional name that identifies the node in String(), logs and tooling
 	Child     Node
 	Duration  time.Duration
+	Clock     Clock // Optional clock used to measure the duration. If nil, the tree's clock is used
 	startTime time.Time
 	status    Status
+	path      string // Path of the node in its tree, assigned by AssignPaths
+}
+
+// Tick executes the WithTimeout node with a background context.
+//
+// Returns:
+//   - The status of the WithTimeout node after execution. See TickContext for details.
+func (wt *WithTimeout) Tick() Status {
+	return wt.TickContext(context.Background())
 }
 
-// Tick executes the WithTimeout node, running its child and enforcing the timeout.
+// TickContext executes the WithTimeout node, running its child and enforcing the timeout.
+//
+// Parameters:
+//   - ctx: The context for this tick. It is passed to the children of the node.
+//     If ctx is cancelled, the node fails without ticking its children.
 //
 // Returns:
 //   - The status of the WithTimeout node after execution, which can be Ready, Running, Success, or Failure.
 //     The node returns Success or Failure if the child returns those statuses before the timeout expires,
-//     and returns Failure if the timeout expires while the child is still Running.
-func (wt *WithTimeout) Tick() Status {
+//     and returns Failure if the timeout expires or the context's deadline passes while the child is still Running.
+func (wt *WithTimeout) TickContext(ctx context.Context) Status {
+	if ctx.Err() != nil {
+		wt.status = Failure
+		return wt.status
+	}
+
 	if wt.Child == nil {
 		wt.status = Failure
 		return wt.status
 	}
 
 	// If this is the first tick, start the timer
+	clock := nodeClock(ctx, wt.Clock)
 	if wt.startTime.IsZero() {
-		wt.startTime = time.Now()
+		wt.startTime = clock.Now()
 	}
 
-	childStatus := wt.Child.Tick()
+	childStatus := TickNode(ctx, wt.Child)
 
 	switch childStatus {
 	case Success, Failure:
 		wt.status = childStatus
 		return wt.status
 	case Running:
-		if time.Since(wt.startTime) >= wt.Duration {
-			wt.status = Failure // Time's up, child is still running
+		if clock.Now().Sub(wt.startTime) >= wt.Duration || ctx.Err() != nil {
+			HaltNode(wt.Child)
+			wt.status = Failure // Time's up or the context is done, child is still running
 			return wt.status
 		}
 		wt.status = Running
@@ -1350,6 +2799,20 @@ func (wt *WithTimeout) Reset() Status {
 	return wt.status
 }
 
+// Halt interrupts the WithTimeout node and its child, returning both to the Ready state.
+//
+// Returns:
+//   - The status of the WithTimeout node after halting, which will be Ready. The child node is halted if it is
+//     Running and reset otherwise.
+func (wt *WithTimeout) Halt() Status {
+	wt.status = Ready
+	wt.startTime = time.Time{}
+	if wt.Child != nil {
+		HaltNode(wt.Child)
+	}
+	return wt.status
+}
+
 // Status returns the current status of the WithTimeout node.
 //
 // Returns:
@@ -1366,7 +2829,7 @@ func (wt *WithTimeout) Status() Status {
 //   - A string that represents the WithTimeout node, including its current status, timeout duration, and the child node (if it exists).
 func (wt *WithTimeout) String() string {
 	var builder strings.Builder
-	builder.WriteString("WithTimeout (")
+	builder.WriteString(nodeLabel("WithTimeout", wt.Name, wt.path) + " (")
 	builder.WriteString(wt.status.String())
 	builder.WriteString(", Duration: ")
 	builder.WriteString(wt.Duration.String())
@@ -1382,26 +2845,77 @@ func (wt *WithTimeout) String() string {
 	return builder.String()
 }
 
+// NodeName returns the optional name of the WithTimeout node.
+//
+// Returns:
+//   - The Name of the node, or the empty string if it has none.
+func (wt *WithTimeout) NodeName() string {
+	return wt.Name
+}
+
+// Path returns the path of the WithTimeout node in its tree, such as "root/0/2".
+//
+// Returns:
+//   - The path assigned by AssignPaths, or the empty string if none has been assigned.
+func (wt *WithTimeout) Path() string {
+	return wt.path
+}
+
+// SetPath sets the path of the WithTimeout node in its tree. It is called by AssignPaths.
+//
+// Parameters:
+//   - path: The path of the node.
+func (wt *WithTimeout) SetPath(path string) {
+	wt.path = path
+}
+
+// ChildNodes returns the child of the WithTimeout node.
+//
+// Returns:
+//   - A slice holding the Child of the node, or an empty slice if it is not set.
+func (wt *WithTimeout) ChildNodes() []Node {
+	return childSlice(wt.Child)
+}
+
 // Log represents a decorator node that executes its child and logs the result.
 // It's useful for debugging and monitoring behavior tree execution.
 type Log struct {
+	Name     string // Optional name that identifies the node in String(), logs and tooling
 	Child    Node
 	Message  string          // Optional custom message for logging
 	LogLevel *slog.Level     // Optional custom log level. If nil, uses default levels based on child status
 	Logger   *slog.Logger    // Optional custom logger. If nil, uses the default logger
-	Context  context.Context // Optional context for logging
+	Context  context.Context // Optional context for logging. If nil, the tick's context is used
 	status   Status
+	path     string // Path of the node in its tree, assigned by AssignPaths
+}
+
+// Tick executes the Log node with a background context.
+//
+// Returns:
+//   - The status of the Log node after execution. See TickContext for details.
+func (l *Log) Tick() Status {
+	return l.TickContext(context.Background())
 }
 
-// Tick executes the Log node, running its child and logging the result.
+// TickContext executes the Log node, running its child and logging the result.
+//
+// Parameters:
+//   - ctx: The context for this tick. It is passed to the children of the node.
+//     If ctx is cancelled, the node fails without ticking its children.
 //
 // Returns:
 //   - The status of the Log node after execution, which can be Ready, Running, Success, or Failure.
 //     The node logs the result of the child execution with the specified message and log level (or defaults based on child status).
-func (l *Log) Tick() Status {
+func (l *Log) TickContext(ctx context.Context) Status {
+	if ctx.Err() != nil {
+		l.status = Failure
+		return l.status
+	}
+
 	logContext := l.Context
 	if logContext == nil {
-		logContext = context.Background()
+		logContext = ctx
 	}
 
 	if l.Child == nil {
@@ -1422,7 +2936,7 @@ func (l *Log) Tick() Status {
 	}
 
 	// Execute the child
-	childStatus := l.Child.Tick()
+	childStatus := TickNode(ctx, l.Child)
 	l.status = childStatus
 
 	// Log the result with context
@@ -1447,16 +2961,27 @@ func (l *Log) Tick() Status {
 		}
 	}
 
-	// Log with the determined level
-	slog.Log(logContext, logLevel, message,
-		"child_status", childStatus.String(),
-		"child_type", l.getChildType(),
-	)
+	// Log with the determined level, identifying the nodes by their names and paths when they have them
+	attrs := []any{"child_status", childStatus.String(), "child_type", l.getChildType()}
+	if name := NameOf(l.Child); name != "" {
+		attrs = append(attrs, "child_name", name)
+	}
+	if path := PathOf(l.Child); path != "" {
+		attrs = append(attrs, "child_path", path)
+	}
+	if l.Name != "" {
+		attrs = append(attrs, "name", l.Name)
+	}
+	if l.path != "" {
+		attrs = append(attrs, "path", l.path)
+	}
+	slog.Log(logContext, logLevel, message, attrs...)
 
 	return l.status
 }
 
-// getChildType returns a string representation of the child node type for logging.
+// getChildType returns a string representation of the child node type for logging. The child is identified
+// by its name and path when it has them, so the type is only needed to tell what kind of node it is.
 //
 // Returns:
 //   - A string representing the type of the child node, or "nil" if there is no child.
@@ -1465,8 +2990,7 @@ func (l *Log) getChildType() string {
 		return "nil"
 	}
 
-	t := reflect.TypeOf(l.Child)
-	return t.Elem().Name()
+	return typeName(l.Child)
 }
 
 // Reset resets the Log node and its child to the Ready state.
@@ -1495,6 +3019,32 @@ func (l *Log) Reset() Status {
 	return l.status
 }
 
+// Halt interrupts the Log node and its child, returning both to the Ready state.
+//
+// Returns:
+//   - The status of the Log node after halting, which will be Ready. The child node is halted if it is
+//     Running and reset otherwise, and the halt is logged.
+func (l *Log) Halt() Status {
+	l.status = Ready
+	if l.Child != nil {
+		HaltNode(l.Child)
+	}
+
+	logContext := l.Context
+	if logContext == nil {
+		logContext = context.Background()
+	}
+
+	// Log halt with custom level if specified, otherwise use Debug
+	logLevel := slog.LevelDebug
+	if l.LogLevel != nil {
+		logLevel = *l.LogLevel
+	}
+
+	slog.Log(logContext, logLevel, "Log node halted", "message", l.Message)
+	return l.status
+}
+
 // Status returns the current status of the Log node.
 //
 // Returns:
@@ -1511,7 +3061,7 @@ func (l *Log) Status() Status {
 //   - A string that represents the Log node, including its current status, message, log level, and the child node (if it exists).
 func (l *Log) String() string {
 	var builder strings.Builder
-	builder.WriteString("Log (")
+	builder.WriteString(nodeLabel("Log", l.Name, l.path) + " (")
 	builder.WriteString(l.status.String())
 	if l.Message != "" {
 		builder.WriteString(", \"")
@@ -1534,3 +3084,35 @@ func (l *Log) String() string {
 	}
 	return builder.String()
 }
+
+// NodeName returns the optional name of the Log node.
+//
+// Returns:
+//   - The Name of the node, or the empty string if it has none.
+func (l *Log) NodeName() string {
+	return l.Name
+}
+
+// Path returns the path of the Log node in its tree, such as "root/0/2".
+//
+// Returns:
+//   - The path assigned by AssignPaths, or the empty string if none has been assigned.
+func (l *Log) Path() string {
+	return l.path
+}
+
+// SetPath sets the path of the Log node in its tree. It is called by AssignPaths.
+//
+// Parameters:
+//   - path: The path of the node.
+func (l *Log) SetPath(path string) {
+	l.path = path
+}
+
+// ChildNodes returns the child of the Log node.
+//
+// Returns:
+//   - A slice holding the Child of the node, or an empty slice if it is not set.
+func (l *Log) ChildNodes() []Node {
+	return childSlice(l.Child)
+}
@@ -1,6 +1,8 @@
 package behave
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"strings"
@@ -60,14 +62,15 @@ func TestWithTimeout_Tick(t *testing.T) {
 	// Action that always returns Success
 	action := &Action{Run: func() Status { return Success }}
 	duration := 200 * time.Millisecond
-	withTimeout := &WithTimeout{Child: action, Duration: duration}
+	clock := NewFakeClock(time.Now())
+	withTimeout := &WithTimeout{Child: action, Duration: duration, Clock: clock}
 
 	// Should return Success immediately since the child always returns Success
 	status := withTimeout.Tick()
 	if status != Success {
 		t.Errorf("WithTimeout.Tick() = %v, want %v (child completes immediately)", status, Success)
 	}
-	time.Sleep(duration + 20*time.Millisecond)
+	clock.Advance(duration + 20*time.Millisecond)
 	// After duration, should still return Success
 	status = withTimeout.Tick()
 	if status != Success {
@@ -79,14 +82,15 @@ func TestWithTimeout_ChildFailure(t *testing.T) {
 	// Action that returns Failure
 	action := &Action{Run: func() Status { return Failure }}
 	duration := 100 * time.Millisecond
-	withTimeout := &WithTimeout{Child: action, Duration: duration}
+	clock := NewFakeClock(time.Now())
+	withTimeout := &WithTimeout{Child: action, Duration: duration, Clock: clock}
 
 	// Should return Failure immediately since the child always returns Failure
 	status := withTimeout.Tick()
 	if status != Failure {
 		t.Errorf("WithTimeout.Tick() = %v, want %v (child completes immediately)", status, Failure)
 	}
-	time.Sleep(duration + 20*time.Millisecond)
+	clock.Advance(duration + 20*time.Millisecond)
 	// After duration, should still return Failure
 	status = withTimeout.Tick()
 	if status != Failure {
@@ -97,11 +101,12 @@ func TestWithTimeout_ChildFailure(t *testing.T) {
 func TestWithTimeout_Reset(t *testing.T) {
 	action := &Action{Run: func() Status { return Success }}
 	duration := 50 * time.Millisecond
-	withTimeout := &WithTimeout{Child: action, Duration: duration}
+	clock := NewFakeClock(time.Now())
+	withTimeout := &WithTimeout{Child: action, Duration: duration, Clock: clock}
 
 	// Run once (should complete immediately)
 	withTimeout.Tick()
-	time.Sleep(duration + 10*time.Millisecond)
+	clock.Advance(duration + 10*time.Millisecond)
 	withTimeout.Tick()
 
 	// Reset should set status to Ready and allow reuse
@@ -635,38 +640,173 @@ func TestParallel_MinSuccessCount_Validation(t *testing.T) {
 		name            string
 		children        []Node
 		minSuccessCount int
-		expectedMin     int
+		expected        Status
+		wantErr         bool
 	}{
 		{
-			name: "zero min success count, should be 1",
+			name: "zero min success count, treated as 1",
 			children: []Node{
 				&Action{Run: func() Status { return Success }},
-				&Action{Run: func() Status { return Success }},
+				&Action{Run: func() Status { return Failure }},
 			},
 			minSuccessCount: 0,
-			expectedMin:     1,
+			expected:        Success,
 		},
 		{
-			name: "min success count greater than children, should be clamped",
+			name: "min success count greater than children, invalid",
 			children: []Node{
 				&Action{Run: func() Status { return Success }},
 			},
 			minSuccessCount: 5,
-			expectedMin:     1,
+			expected:        Failure,
+			wantErr:         true,
+		},
+		{
+			name: "negative min success count, invalid",
+			children: []Node{
+				&Action{Run: func() Status { return Success }},
+			},
+			minSuccessCount: -1,
+			expected:        Failure,
+			wantErr:         true,
 		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			parallel := &Parallel{Children: test.children, MinSuccessCount: test.minSuccessCount}
-			parallel.Tick() // This will validate and adjust MinSuccessCount
-			if parallel.MinSuccessCount != test.expectedMin {
-				t.Errorf("Parallel.MinSuccessCount after Tick() = %v, want %v", parallel.MinSuccessCount, test.expectedMin)
+			err := parallel.Validate()
+			if (err != nil) != test.wantErr {
+				t.Errorf("Parallel.Validate() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidConfig) {
+				t.Errorf("Parallel.Validate() error = %v, want it to wrap ErrInvalidConfig", err)
+			}
+			if status := parallel.Tick(); status != test.expected {
+				t.Errorf("Parallel.Tick() = %v, want %v", status, test.expected)
+			}
+			// The configuration is never modified
+			if parallel.MinSuccessCount != test.minSuccessCount {
+				t.Errorf("Parallel.MinSuccessCount after Tick() = %v, want %v", parallel.MinSuccessCount, test.minSuccessCount)
+			}
+		})
+	}
+}
+
+func TestParallel_Policies(t *testing.T) {
+	succeed := func() Node { return &Action{Run: func() Status { return Success }} }
+	fail := func() Node { return &Action{Run: func() Status { return Failure }} }
+	run := func() Node { return &Action{Run: func() Status { return Running }} }
+
+	tests := []struct {
+		name     string
+		parallel *Parallel
+		expected Status
+	}{
+		{
+			name:     "threshold, min failure reached",
+			parallel: &Parallel{Children: []Node{fail(), run(), run()}, MinSuccessCount: 1, MinFailureCount: 1},
+			expected: Failure,
+		},
+		{
+			name:     "threshold, min failure not reached",
+			parallel: &Parallel{Children: []Node{fail(), run(), run()}, MinSuccessCount: 1, MinFailureCount: 2},
+			expected: Running,
+		},
+		{
+			name:     "all, all succeed",
+			parallel: &Parallel{Children: []Node{succeed(), succeed()}, Policy: ParallelAll},
+			expected: Success,
+		},
+		{
+			name:     "all, one running",
+			parallel: &Parallel{Children: []Node{succeed(), run()}, Policy: ParallelAll},
+			expected: Running,
+		},
+		{
+			name:     "all, one fails",
+			parallel: &Parallel{Children: []Node{run(), fail()}, Policy: ParallelAll},
+			expected: Failure,
+		},
+		{
+			name:     "any, one succeeds",
+			parallel: &Parallel{Children: []Node{fail(), run(), succeed()}, Policy: ParallelAny},
+			expected: Success,
+		},
+		{
+			name:     "any, some fail",
+			parallel: &Parallel{Children: []Node{fail(), run()}, Policy: ParallelAny},
+			expected: Running,
+		},
+		{
+			name:     "any, all fail",
+			parallel: &Parallel{Children: []Node{fail(), fail()}, Policy: ParallelAny},
+			expected: Failure,
+		},
+		{
+			name:     "race, first finisher fails",
+			parallel: &Parallel{Children: []Node{run(), fail(), succeed()}, Policy: ParallelRace},
+			expected: Failure,
+		},
+		{
+			name:     "race, nothing finished",
+			parallel: &Parallel{Children: []Node{run(), run()}, Policy: ParallelRace},
+			expected: Running,
+		},
+		{
+			name:     "unknown policy",
+			parallel: &Parallel{Children: []Node{succeed()}, Policy: ParallelPolicy(42)},
+			expected: Failure,
+		},
+		{
+			name:     "invalid min failure count",
+			parallel: &Parallel{Children: []Node{succeed()}, MinFailureCount: 2},
+			expected: Failure,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if status := test.parallel.Tick(); status != test.expected {
+				t.Errorf("Parallel.Tick() = %v, want %v", status, test.expected)
 			}
 		})
 	}
 }
 
+func TestParallel_KeepRunning(t *testing.T) {
+	halted := 0
+	children := []Node{
+		&Action{Run: func() Status { return Success }},
+		&Action{Run: func() Status { return Running }, OnHalt: func() { halted++ }},
+	}
+
+	parallel := &Parallel{Children: children, Policy: ParallelAny, KeepRunning: true}
+	if status := parallel.Tick(); status != Success {
+		t.Errorf("Parallel.Tick() = %v, want %v", status, Success)
+	}
+	if halted != 0 {
+		t.Errorf("OnHalt called %d times with KeepRunning, want 0", halted)
+	}
+
+	parallel.KeepRunning = false
+	parallel.Tick()
+	if halted != 1 {
+		t.Errorf("OnHalt called %d times without KeepRunning, want 1", halted)
+	}
+}
+
+func TestParallel_String_Policy(t *testing.T) {
+	parallel := &Parallel{Policy: ParallelRace, MinFailureCount: 2}
+	str := parallel.String()
+	if !strings.Contains(str, "Parallel (Ready, Policy: Race, MinSuccess: 0, MinFailure: 2)") {
+		t.Errorf("Parallel.String() = %q, want it to include the policy and failure count", str)
+	}
+	if ParallelPolicy(42).String() != "Unknown" {
+		t.Errorf("ParallelPolicy(42).String() = %q, want %q", ParallelPolicy(42).String(), "Unknown")
+	}
+}
+
 func TestParallel_Reset(t *testing.T) {
 	children := []Node{
 		&Action{Run: func() Status { return Success }},
@@ -1055,6 +1195,106 @@ func TestRetry_String(t *testing.T) {
 	}
 }
 
+func TestRetry_MaxAttempts(t *testing.T) {
+	runs := 0
+	retry := &Retry{
+		Child:       &Action{Run: func() Status { runs++; return Failure }},
+		MaxAttempts: 3,
+	}
+
+	expected := []Status{Running, Running, Failure, Failure}
+	for i, want := range expected {
+		if status := retry.Tick(); status != want {
+			t.Errorf("Retry.Tick() call %d = %v, want %v", i+1, status, want)
+		}
+	}
+	if runs != 3 {
+		t.Errorf("Child ran %d times, want 3", runs)
+	}
+	if retry.Attempts() != 3 {
+		t.Errorf("Retry.Attempts() = %d, want 3", retry.Attempts())
+	}
+	if str := retry.String(); !strings.Contains(str, "Retry (Failure, Attempts: 3/3)") {
+		t.Errorf("Retry.String() = %q, want it to contain the attempt counter", str)
+	}
+
+	// Reset starts counting again
+	retry.Reset()
+	if retry.Attempts() != 0 {
+		t.Errorf("Retry.Attempts() after Reset() = %d, want 0", retry.Attempts())
+	}
+	if status := retry.Tick(); status != Running {
+		t.Errorf("Retry.Tick() after Reset() = %v, want %v", status, Running)
+	}
+}
+
+func TestRetry_Backoff(t *testing.T) {
+	runs := 0
+	clock := NewFakeClock(time.Now())
+	retry := &Retry{
+		Child:   &Action{Run: func() Status { runs++; return Failure }},
+		Backoff: FixedBackoff{Interval: 30 * time.Millisecond},
+		Clock:   clock,
+	}
+
+	retry.Tick()
+	// Ticks during the backoff delay report Running without running the child
+	if status := retry.Tick(); status != Running {
+		t.Errorf("Retry.Tick() during backoff = %v, want %v", status, Running)
+	}
+	if runs != 1 {
+		t.Errorf("Child ran %d times during backoff, want 1", runs)
+	}
+
+	clock.Advance(30 * time.Millisecond)
+	retry.Tick()
+	if runs != 2 {
+		t.Errorf("Child ran %d times after backoff, want 2", runs)
+	}
+	if str := retry.String(); !strings.Contains(str, "Retry (Running, Attempts: 2)") {
+		t.Errorf("Retry.String() = %q, want it to contain the attempt counter", str)
+	}
+}
+
+func TestRetry_Retryable(t *testing.T) {
+	bt := New(&Retry{
+		Child: &Action{RunWithBlackboard: func(bb *Blackboard) Status {
+			count, _ := bb.GetInt("count")
+			bb.Set("count", count+1)
+			if count >= 1 {
+				bb.Set("error", "permanent")
+			}
+			return Failure
+		}},
+		Retryable: func(ctx context.Context, attempt int) bool {
+			errorKind, _ := BlackboardFromContext(ctx).GetString("error")
+			return errorKind != "permanent"
+		},
+	})
+
+	if status := bt.Tick(); status != Running {
+		t.Errorf("BehaviorTree.Tick() after a transient failure = %v, want %v", status, Running)
+	}
+	if status := bt.Tick(); status != Failure {
+		t.Errorf("BehaviorTree.Tick() after a permanent failure = %v, want %v", status, Failure)
+	}
+}
+
+func TestRetry_CancelledTick(t *testing.T) {
+	runs := 0
+	retry := &Retry{Child: &Action{Run: func() Status { runs++; return Failure }}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if status := retry.TickContext(ctx); status != Failure {
+		t.Errorf("Retry.TickContext() with cancelled context = %v, want %v", status, Failure)
+	}
+	// A cancelled tick is not a failed attempt, so the next tick runs the child
+	if status := retry.Tick(); status != Running || runs != 1 {
+		t.Errorf("Retry.Tick() after a cancelled tick = %v with %d runs, want %v with 1 run", status, runs, Running)
+	}
+}
+
 func TestRepeat_Tick(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -3036,3 +3276,285 @@ func TestComplexBehaviorTreeWithLog(t *testing.T) {
 		}
 	}
 }
+
+func TestBehaviorTree_TickContext(t *testing.T) {
+	type requestKey struct{}
+
+	var seen any
+	action := &Action{
+		RunContext: func(ctx context.Context) Status {
+			seen = ctx.Value(requestKey{})
+			if BlackboardFromContext(ctx) == nil {
+				return Failure
+			}
+			return Success
+		},
+	}
+	bt := New(&Sequence{Children: []Node{&Invert{Child: &Invert{Child: action}}}})
+
+	ctx := context.WithValue(context.Background(), requestKey{}, "request-1")
+	status := bt.TickContext(ctx)
+	if status != Success {
+		t.Errorf("BehaviorTree.TickContext() = %v, want %v", status, Success)
+	}
+	if seen != "request-1" {
+		t.Errorf("Action received context value %v, want %v", seen, "request-1")
+	}
+}
+
+func TestTickContext_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name string
+		node ContextNode
+	}{
+		{name: "action", node: &Action{Run: func() Status { return Success }}},
+		{name: "condition", node: &Condition{Check: func() bool { return true }}},
+		{name: "sequence", node: &Sequence{}},
+		{name: "selector", node: &Selector{Children: []Node{&Action{Run: func() Status { return Success }}}}},
+		{name: "parallel", node: &Parallel{}},
+		{name: "composite", node: &Composite{Child: &Action{Run: func() Status { return Success }}}},
+		{name: "invert", node: &Invert{Child: &Action{Run: func() Status { return Failure }}}},
+		{name: "always success", node: &AlwaysSuccess{}},
+		{name: "retry", node: &Retry{Child: &Action{Run: func() Status { return Failure }}}},
+		{name: "forever", node: &Forever{}},
+		{name: "while failure", node: &WhileFailure{}},
+		{name: "with timeout", node: &WithTimeout{Child: &Action{Run: func() Status { return Success }}, Duration: time.Second}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if status := test.node.TickContext(ctx); status != Failure {
+				t.Errorf("%T.TickContext() with cancelled context = %v, want %v", test.node, status, Failure)
+			}
+		})
+	}
+}
+
+func TestTickContext_CancelStopsRunningAction(t *testing.T) {
+	runs := 0
+	action := &Action{Run: func() Status {
+		runs++
+		return Running
+	}}
+	bt := New(&Sequence{Children: []Node{action}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if status := bt.TickContext(ctx); status != Running {
+		t.Errorf("BehaviorTree.TickContext() = %v, want %v", status, Running)
+	}
+	cancel()
+	if status := bt.TickContext(ctx); status != Failure {
+		t.Errorf("BehaviorTree.TickContext() after cancel = %v, want %v", status, Failure)
+	}
+	if runs != 1 {
+		t.Errorf("Action ran %d times, want 1", runs)
+	}
+}
+
+func TestWithTimeout_ContextDeadline(t *testing.T) {
+	action := &Action{Run: func() Status { return Running }}
+	withTimeout := &WithTimeout{Child: action, Duration: time.Hour}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if status := withTimeout.TickContext(ctx); status != Running {
+		t.Errorf("WithTimeout.TickContext() = %v, want %v", status, Running)
+	}
+	<-ctx.Done()
+	if status := withTimeout.TickContext(ctx); status != Failure {
+		t.Errorf("WithTimeout.TickContext() after context deadline = %v, want %v", status, Failure)
+	}
+}
+
+func TestTickNode_PlainNode(t *testing.T) {
+	ticks := 0
+	node := &testNode{
+		tickFunc:   func() Status { ticks++; return Success },
+		resetFunc:  func() Status { return Ready },
+		statusFunc: func() Status { return Success },
+		stringFunc: func() string { return "testNode" },
+	}
+	if status := TickNode(context.Background(), node); status != Success {
+		t.Errorf("TickNode() = %v, want %v", status, Success)
+	}
+	if ticks != 1 {
+		t.Errorf("TickNode() ticked the node %d times, want 1", ticks)
+	}
+}
+
+func TestAction_Halt(t *testing.T) {
+	halted := 0
+	action := &Action{
+		Run:    func() Status { return Running },
+		OnHalt: func() { halted++ },
+	}
+
+	// Halting an action that isn't running doesn't call OnHalt
+	if status := action.Halt(); status != Ready {
+		t.Errorf("Action.Halt() = %v, want %v", status, Ready)
+	}
+	if halted != 0 {
+		t.Errorf("OnHalt called %d times for an idle action, want 0", halted)
+	}
+
+	action.Tick()
+	if status := action.Halt(); status != Ready {
+		t.Errorf("Action.Halt() = %v, want %v", status, Ready)
+	}
+	if halted != 1 {
+		t.Errorf("OnHalt called %d times, want 1", halted)
+	}
+	if action.Status() != Ready {
+		t.Errorf("Action.Status() after Halt() = %v, want %v", action.Status(), Ready)
+	}
+}
+
+func TestSelector_HaltsPreemptedChild(t *testing.T) {
+	guard := false
+	halted := 0
+	highPriority := &Action{Run: func() Status {
+		if guard {
+			return Success
+		}
+		return Failure
+	}}
+	lowPriority := &Action{
+		Run:    func() Status { return Running },
+		OnHalt: func() { halted++ },
+	}
+	selector := &Selector{Children: []Node{highPriority, lowPriority}}
+
+	if status := selector.Tick(); status != Running {
+		t.Errorf("Selector.Tick() = %v, want %v", status, Running)
+	}
+	if status := selector.Tick(); status != Running {
+		t.Errorf("Selector.Tick() = %v, want %v", status, Running)
+	}
+	if halted != 0 {
+		t.Errorf("OnHalt called %d times while the child keeps running, want 0", halted)
+	}
+
+	guard = true
+	if status := selector.Tick(); status != Success {
+		t.Errorf("Selector.Tick() = %v, want %v", status, Success)
+	}
+	if halted != 1 {
+		t.Errorf("OnHalt called %d times after preemption, want 1", halted)
+	}
+	if lowPriority.Status() != Ready {
+		t.Errorf("Preempted child status = %v, want %v", lowPriority.Status(), Ready)
+	}
+}
+
+func TestComposite_HaltsChildWhenConditionFails(t *testing.T) {
+	allowed := true
+	halted := 0
+	child := &Action{
+		Run:    func() Status { return Running },
+		OnHalt: func() { halted++ },
+	}
+	composite := &Composite{
+		Conditions: []Node{&Condition{Check: func() bool { return allowed }}},
+		Child:      child,
+	}
+
+	if status := composite.Tick(); status != Running {
+		t.Errorf("Composite.Tick() = %v, want %v", status, Running)
+	}
+	allowed = false
+	if status := composite.Tick(); status != Failure {
+		t.Errorf("Composite.Tick() = %v, want %v", status, Failure)
+	}
+	if halted != 1 {
+		t.Errorf("OnHalt called %d times, want 1", halted)
+	}
+
+	// The child is not halted again on subsequent failures
+	composite.Tick()
+	if halted != 1 {
+		t.Errorf("OnHalt called %d times, want 1", halted)
+	}
+}
+
+func TestParallel_HaltsRunningChildrenWhenDecided(t *testing.T) {
+	halted := 0
+	parallel := &Parallel{
+		Children: []Node{
+			&Action{Run: func() Status { return Success }},
+			&Action{Run: func() Status { return Running }, OnHalt: func() { halted++ }},
+		},
+		MinSuccessCount: 1,
+	}
+
+	if status := parallel.Tick(); status != Success {
+		t.Errorf("Parallel.Tick() = %v, want %v", status, Success)
+	}
+	if halted != 1 {
+		t.Errorf("OnHalt called %d times, want 1", halted)
+	}
+}
+
+func TestWithTimeout_HaltsChildOnTimeout(t *testing.T) {
+	halted := 0
+	clock := NewFakeClock(time.Now())
+	withTimeout := &WithTimeout{
+		Child:    &Action{Run: func() Status { return Running }, OnHalt: func() { halted++ }},
+		Duration: 10 * time.Millisecond,
+		Clock:    clock,
+	}
+
+	withTimeout.Tick()
+	clock.Advance(10 * time.Millisecond)
+	if status := withTimeout.Tick(); status != Failure {
+		t.Errorf("WithTimeout.Tick() after timeout = %v, want %v", status, Failure)
+	}
+	if halted != 1 {
+		t.Errorf("OnHalt called %d times, want 1", halted)
+	}
+}
+
+func TestBehaviorTree_Halt(t *testing.T) {
+	halted := 0
+	sequence := &Sequence{Children: []Node{
+		&Action{Run: func() Status { return Success }},
+		&RepeatN{
+			Child:    &Action{Run: func() Status { return Running }, OnHalt: func() { halted++ }},
+			MaxCount: 3,
+		},
+	}}
+	bt := New(sequence)
+
+	if status := bt.Tick(); status != Running {
+		t.Errorf("BehaviorTree.Tick() = %v, want %v", status, Running)
+	}
+	bt.Halt()
+	if halted != 1 {
+		t.Errorf("OnHalt called %d times, want 1", halted)
+	}
+	if bt.Status() != Ready {
+		t.Errorf("BehaviorTree.Status() after Halt() = %v, want %v", bt.Status(), Ready)
+	}
+	if sequence.Status() != Ready {
+		t.Errorf("Sequence.Status() after Halt() = %v, want %v", sequence.Status(), Ready)
+	}
+}
+
+func TestHaltNode_FallsBackToReset(t *testing.T) {
+	resets := 0
+	node := &testNode{
+		tickFunc:   func() Status { return Running },
+		resetFunc:  func() Status { resets++; return Ready },
+		statusFunc: func() Status { return Running },
+		stringFunc: func() string { return "testNode" },
+	}
+	if status := HaltNode(node); status != Ready {
+		t.Errorf("HaltNode() = %v, want %v", status, Ready)
+	}
+	if resets != 1 {
+		t.Errorf("HaltNode() reset the node %d times, want 1", resets)
+	}
+}
@@ -0,0 +1,284 @@
+package behave
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Blackboard is a key/value store shared by the nodes of a behavior tree. It allows Actions and
+// Conditions to pass data to one another without relying on closures over shared variables.
+//
+// A Blackboard is safe for concurrent use. The zero value is an empty Blackboard ready for use. A nil
+// *Blackboard, such as the one a node ticked outside a BehaviorTree sees, is empty and read-only: reading
+// from it finds nothing and writing to it has no effect.
+type Blackboard struct {
+	mu   sync.RWMutex
+	data map[string]any
+}
+
+// NewBlackboard creates a new, empty Blackboard.
+//
+// Returns:
+//   - A pointer to a new Blackboard instance with no entries.
+func NewBlackboard() *Blackboard {
+	return &Blackboard{data: make(map[string]any)}
+}
+
+// Set stores a value on the Blackboard under the given key, replacing any existing value.
+//
+// Parameters:
+//   - key: The key under which to store the value.
+//   - value: The value to store.
+func (b *Blackboard) Set(key string, value any) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.data == nil {
+		b.data = make(map[string]any)
+	}
+	b.data[key] = value
+}
+
+// Get retrieves the value stored on the Blackboard under the given key.
+//
+// Parameters:
+//   - key: The key of the value to retrieve.
+//
+// Returns:
+//   - The value stored under the key, or nil if the key is not present.
+//   - true if the key is present, false otherwise.
+func (b *Blackboard) Get(key string) (any, bool) {
+	if b == nil {
+		return nil, false
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	value, ok := b.data[key]
+	return value, ok
+}
+
+// Has reports whether the Blackboard contains the given key.
+//
+// Parameters:
+//   - key: The key to look up.
+//
+// Returns:
+//   - true if the key is present, false otherwise.
+func (b *Blackboard) Has(key string) bool {
+	_, ok := b.Get(key)
+	return ok
+}
+
+// Delete removes the given key from the Blackboard. Deleting a key that is not present is a no-op.
+//
+// Parameters:
+//   - key: The key to remove.
+func (b *Blackboard) Delete(key string) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.data, key)
+}
+
+// Clear removes all entries from the Blackboard.
+func (b *Blackboard) Clear() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.data = make(map[string]any)
+}
+
+// Len returns the number of entries stored on the Blackboard.
+//
+// Returns:
+//   - The number of keys currently present.
+func (b *Blackboard) Len() int {
+	if b == nil {
+		return 0
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.data)
+}
+
+// Keys returns the keys stored on the Blackboard in sorted order.
+//
+// Returns:
+//   - A sorted slice containing every key currently present.
+func (b *Blackboard) Keys() []string {
+	if b == nil {
+		return []string{}
+	}
+	b.mu.RLock()
+	keys := make([]string, 0, len(b.data))
+	for key := range b.data {
+		keys = append(keys, key)
+	}
+	b.mu.RUnlock()
+	sort.Strings(keys)
+	return keys
+}
+
+// Range calls fn for each entry on the Blackboard in sorted key order. Iteration stops if fn returns false.
+// Range operates on a snapshot of the Blackboard, so fn may safely modify the Blackboard.
+//
+// Parameters:
+//   - fn: The function to call for each key/value pair.
+func (b *Blackboard) Range(fn func(key string, value any) bool) {
+	if b == nil {
+		return
+	}
+	b.mu.RLock()
+	snapshot := make(map[string]any, len(b.data))
+	for key, value := range b.data {
+		snapshot[key] = value
+	}
+	b.mu.RUnlock()
+
+	keys := make([]string, 0, len(snapshot))
+	for key := range snapshot {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !fn(key, snapshot[key]) {
+			return
+		}
+	}
+}
+
+// GetString retrieves a string value from the Blackboard.
+//
+// Returns:
+//   - The string stored under the key, or "" if the key is not present or the value is not a string.
+//   - true if the key is present and holds a string, false otherwise.
+func (b *Blackboard) GetString(key string) (string, bool) {
+	return GetAs[string](b, key)
+}
+
+// GetInt retrieves an int value from the Blackboard.
+//
+// Returns:
+//   - The int stored under the key, or 0 if the key is not present or the value is not an int.
+//   - true if the key is present and holds an int, false otherwise.
+func (b *Blackboard) GetInt(key string) (int, bool) {
+	return GetAs[int](b, key)
+}
+
+// GetFloat64 retrieves a float64 value from the Blackboard.
+//
+// Returns:
+//   - The float64 stored under the key, or 0 if the key is not present or the value is not a float64.
+//   - true if the key is present and holds a float64, false otherwise.
+func (b *Blackboard) GetFloat64(key string) (float64, bool) {
+	return GetAs[float64](b, key)
+}
+
+// GetBool retrieves a bool value from the Blackboard.
+//
+// Returns:
+//   - The bool stored under the key, or false if the key is not present or the value is not a bool.
+//   - true if the key is present and holds a bool, false otherwise.
+func (b *Blackboard) GetBool(key string) (bool, bool) {
+	return GetAs[bool](b, key)
+}
+
+// String returns a string representation of the Blackboard.
+//
+// Returns:
+//   - A string listing every entry in sorted key order. The format is "Blackboard {key: value, ...}".
+func (b *Blackboard) String() string {
+	var builder strings.Builder
+	builder.WriteString("Blackboard {")
+	first := true
+	b.Range(func(key string, value any) bool {
+		if !first {
+			builder.WriteString(", ")
+		}
+		first = false
+		builder.WriteString(key)
+		builder.WriteString(": ")
+		builder.WriteString(fmt.Sprint(value))
+		return true
+	})
+	builder.WriteString("}")
+	return builder.String()
+}
+
+// GetAs retrieves a value of type T from the Blackboard.
+//
+// Parameters:
+//   - b: The Blackboard to read from.
+//   - key: The key of the value to retrieve.
+//
+// Returns:
+//   - The value stored under the key, or the zero value of T if the key is not present or the value is not a T.
+//   - true if the key is present and holds a T, false otherwise.
+func GetAs[T any](b *Blackboard, key string) (T, bool) {
+	var zero T
+	if b == nil {
+		return zero, false
+	}
+	value, ok := b.Get(key)
+	if !ok {
+		return zero, false
+	}
+	typed, ok := value.(T)
+	if !ok {
+		return zero, false
+	}
+	return typed, true
+}
+
+// BlackboardUser is implemented by nodes that read from or write to a Blackboard.
+// BehaviorTree.Tick binds the tree's Blackboard to every node in the tree that implements this interface.
+type BlackboardUser interface {
+	SetBlackboard(bb *Blackboard)
+}
+
+// bindBlackboard binds the Blackboard to the node and all of its descendants that implement BlackboardUser.
+func bindBlackboard(node Node, bb *Blackboard) {
+	_ = Walk(node, PreOrder, func(node Node, depth int, path string) error {
+		if user, ok := node.(BlackboardUser); ok {
+			user.SetBlackboard(bb)
+		}
+		return nil
+	})
+}
+
+// blackboardKey is the context key under which a Blackboard is stored.
+type blackboardKey struct{}
+
+// WithBlackboard returns a copy of ctx that carries the given Blackboard. BehaviorTree.TickContext uses it to
+// make the tree's Blackboard available to RunContext and CheckContext functions.
+//
+// Parameters:
+//   - ctx: The parent context.
+//   - bb: The Blackboard to add to the context.
+//
+// Returns:
+//   - A new context carrying the Blackboard.
+func WithBlackboard(ctx context.Context, bb *Blackboard) context.Context {
+	return context.WithValue(ctx, blackboardKey{}, bb)
+}
+
+// BlackboardFromContext returns the Blackboard carried by ctx.
+//
+// Parameters:
+//   - ctx: The context to read from.
+//
+// Returns:
+//   - The Blackboard carried by the context, or nil if there is none.
+func BlackboardFromContext(ctx context.Context) *Blackboard {
+	bb, _ := ctx.Value(blackboardKey{}).(*Blackboard)
+	return bb
+}
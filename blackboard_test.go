@@ -0,0 +1,255 @@
+package behave
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestBlackboard_SetGetDelete(t *testing.T) {
+	bb := NewBlackboard()
+
+	if bb.Has("target") {
+		t.Errorf("Blackboard.Has() on empty blackboard = true, want false")
+	}
+
+	bb.Set("target", "door")
+	value, ok := bb.Get("target")
+	if !ok || value != "door" {
+		t.Errorf("Blackboard.Get() = %v, %v, want %v, %v", value, ok, "door", true)
+	}
+	if !bb.Has("target") {
+		t.Errorf("Blackboard.Has() after Set() = false, want true")
+	}
+	if bb.Len() != 1 {
+		t.Errorf("Blackboard.Len() = %d, want 1", bb.Len())
+	}
+
+	bb.Delete("target")
+	if bb.Has("target") {
+		t.Errorf("Blackboard.Has() after Delete() = true, want false")
+	}
+
+	bb.Set("a", 1)
+	bb.Set("b", 2)
+	bb.Clear()
+	if bb.Len() != 0 {
+		t.Errorf("Blackboard.Len() after Clear() = %d, want 0", bb.Len())
+	}
+}
+
+func TestBlackboard_ZeroValue(t *testing.T) {
+	var bb Blackboard
+	bb.Set("key", "value")
+	if value, ok := bb.GetString("key"); !ok || value != "value" {
+		t.Errorf("Blackboard.GetString() on zero value = %v, %v, want %v, %v", value, ok, "value", true)
+	}
+}
+
+func TestBlackboard_Nil(t *testing.T) {
+	var bb *Blackboard
+	bb.Set("key", "value")
+	bb.Delete("key")
+	bb.Clear()
+	if _, ok := bb.Get("key"); ok || bb.Has("key") || bb.Len() != 0 || len(bb.Keys()) != 0 {
+		t.Errorf("a nil Blackboard should be empty")
+	}
+	bb.Range(func(key string, value any) bool {
+		t.Errorf("Blackboard.Range() on nil called fn with %q", key)
+		return true
+	})
+	if str := bb.String(); str != "Blackboard {}" {
+		t.Errorf("Blackboard.String() on nil = %q, want %q", str, "Blackboard {}")
+	}
+
+	// An action ticked outside a BehaviorTree has no Blackboard
+	action := &Action{RunWithBlackboard: func(bb *Blackboard) Status {
+		if count, ok := bb.GetInt("count"); ok {
+			bb.Set("count", count+1)
+		}
+		return Success
+	}}
+	if status := action.Tick(); status != Success {
+		t.Errorf("Action.Tick() without a Blackboard = %v, want %v", status, Success)
+	}
+}
+
+func TestBlackboard_TypedAccessors(t *testing.T) {
+	bb := NewBlackboard()
+	bb.Set("name", "robot")
+	bb.Set("count", 3)
+	bb.Set("speed", 1.5)
+	bb.Set("armed", true)
+
+	if value, ok := bb.GetString("name"); !ok || value != "robot" {
+		t.Errorf("Blackboard.GetString() = %v, %v, want %v, %v", value, ok, "robot", true)
+	}
+	if value, ok := bb.GetInt("count"); !ok || value != 3 {
+		t.Errorf("Blackboard.GetInt() = %v, %v, want %v, %v", value, ok, 3, true)
+	}
+	if value, ok := bb.GetFloat64("speed"); !ok || value != 1.5 {
+		t.Errorf("Blackboard.GetFloat64() = %v, %v, want %v, %v", value, ok, 1.5, true)
+	}
+	if value, ok := bb.GetBool("armed"); !ok || !value {
+		t.Errorf("Blackboard.GetBool() = %v, %v, want %v, %v", value, ok, true, true)
+	}
+
+	// Wrong type returns the zero value
+	if value, ok := bb.GetInt("name"); ok || value != 0 {
+		t.Errorf("Blackboard.GetInt() on string = %v, %v, want %v, %v", value, ok, 0, false)
+	}
+	// Missing key returns the zero value
+	if value, ok := GetAs[[]string](bb, "missing"); ok || value != nil {
+		t.Errorf("GetAs() on missing key = %v, %v, want %v, %v", value, ok, nil, false)
+	}
+	if _, ok := GetAs[int](nil, "count"); ok {
+		t.Errorf("GetAs() on nil blackboard returned ok = true, want false")
+	}
+}
+
+func TestBlackboard_KeysAndRange(t *testing.T) {
+	bb := NewBlackboard()
+	bb.Set("c", 3)
+	bb.Set("a", 1)
+	bb.Set("b", 2)
+
+	keys := bb.Keys()
+	if strings.Join(keys, ",") != "a,b,c" {
+		t.Errorf("Blackboard.Keys() = %v, want [a b c]", keys)
+	}
+
+	var visited []string
+	bb.Range(func(key string, value any) bool {
+		visited = append(visited, key)
+		// Modifying the blackboard while ranging must not deadlock
+		bb.Set(key+"-seen", true)
+		return key != "b"
+	})
+	if strings.Join(visited, ",") != "a,b" {
+		t.Errorf("Blackboard.Range() visited %v, want [a b]", visited)
+	}
+
+	str := NewBlackboard().String()
+	if str != "Blackboard {}" {
+		t.Errorf("Blackboard.String() = %q, want %q", str, "Blackboard {}")
+	}
+	bb = NewBlackboard()
+	bb.Set("x", 1)
+	bb.Set("y", "two")
+	if str := bb.String(); str != "Blackboard {x: 1, y: two}" {
+		t.Errorf("Blackboard.String() = %q, want %q", str, "Blackboard {x: 1, y: two}")
+	}
+}
+
+func TestBlackboard_Concurrent(t *testing.T) {
+	bb := NewBlackboard()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				bb.Set("key", i*j)
+				bb.Get("key")
+				bb.Keys()
+			}
+		}(i)
+	}
+	wg.Wait()
+	if !bb.Has("key") {
+		t.Errorf("Blackboard.Has() after concurrent writes = false, want true")
+	}
+}
+
+func TestBehaviorTree_Blackboard(t *testing.T) {
+	writer := &Action{
+		RunWithBlackboard: func(bb *Blackboard) Status {
+			bb.Set("door", "open")
+			return Success
+		},
+	}
+	reader := &Condition{
+		CheckWithBlackboard: func(bb *Blackboard) bool {
+			value, _ := bb.GetString("door")
+			return value == "open"
+		},
+	}
+	bt := New(&Sequence{Children: []Node{
+		writer,
+		&Invert{Child: &Invert{Child: reader}},
+	}})
+
+	status := bt.Tick()
+	if status != Success {
+		t.Errorf("BehaviorTree.Tick() = %v, want %v", status, Success)
+	}
+	if value, _ := bt.Blackboard.GetString("door"); value != "open" {
+		t.Errorf("BehaviorTree.Blackboard[door] = %v, want %v", value, "open")
+	}
+}
+
+func TestBehaviorTree_NilBlackboard(t *testing.T) {
+	var seen *Blackboard
+	action := &Action{
+		RunWithBlackboard: func(bb *Blackboard) Status {
+			seen = bb
+			return Success
+		},
+	}
+	bt := &BehaviorTree{Root: action}
+	bt.Tick()
+	if bt.Blackboard == nil {
+		t.Fatalf("BehaviorTree.Blackboard after Tick() = nil, want a Blackboard")
+	}
+	if seen != bt.Blackboard {
+		t.Errorf("Action received %p, want the tree's Blackboard %p", seen, bt.Blackboard)
+	}
+}
+
+// countingUser is a leaf node that counts how often a Blackboard is bound to it.
+type countingUser struct {
+	Action
+	binds int
+}
+
+func (c *countingUser) SetBlackboard(bb *Blackboard) {
+	c.binds++
+	c.Action.SetBlackboard(bb)
+}
+
+func TestBehaviorTree_BindsBlackboardOnChange(t *testing.T) {
+	var seen *Blackboard
+	user := &countingUser{Action: Action{RunWithBlackboard: func(bb *Blackboard) Status {
+		seen = bb
+		return Success
+	}}}
+	bt := New(&Invert{Child: user})
+	bt.Tick()
+	bt.Tick()
+	if user.binds != 1 {
+		t.Errorf("Blackboard bound %d times over 2 ticks, want once when the tree was created", user.binds)
+	}
+
+	bt.Blackboard = NewBlackboard()
+	bt.Tick()
+	if seen != bt.Blackboard || user.binds != 2 {
+		t.Errorf("a replaced Blackboard was bound %d times in total, want it bound before the next tick", user.binds)
+	}
+}
+
+func TestAction_RunTakesPrecedence(t *testing.T) {
+	ranWithBlackboard := false
+	action := &Action{
+		Run: func() Status { return Running },
+		RunWithBlackboard: func(bb *Blackboard) Status {
+			ranWithBlackboard = true
+			return Success
+		},
+	}
+	if status := New(action).Tick(); status != Running {
+		t.Errorf("Action.Tick() = %v, want %v", status, Running)
+	}
+	if ranWithBlackboard {
+		t.Errorf("RunWithBlackboard was called even though Run is set")
+	}
+}
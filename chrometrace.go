@@ -0,0 +1,164 @@
+package behave
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// chromeTraceEvent is an event of the Trace Event Format read by chrome://tracing and Perfetto.
+type chromeTraceEvent struct {
+	Name      string         `json:"name"`
+	Category  string         `json:"cat,omitempty"`
+	Phase     string         `json:"ph"`            // "X" for a complete span, "M" for metadata
+	Timestamp float64        `json:"ts"`            // Start of the span, in microseconds
+	Duration  *float64       `json:"dur,omitempty"` // Length of the span, in microseconds. Only set for spans
+	Process   int            `json:"pid"`
+	Thread    int            `json:"tid"`
+	Args      map[string]any `json:"args,omitempty"`
+}
+
+// chromeSpan is a span of a node, with the index of the event at which the node was entered.
+type chromeSpan struct {
+	enter int
+	event chromeTraceEvent
+}
+
+// chromeTrace is the top-level object of a Trace Event Format file.
+type chromeTrace struct {
+	TraceEvents     []chromeTraceEvent `json:"traceEvents"`
+	DisplayTimeUnit string             `json:"displayTimeUnit"`
+}
+
+// WriteChromeTrace writes recorded ticks in the Trace Event Format, as JSON that can be opened in chrome://tracing
+// or https://ui.perfetto.dev. Every tick of every node becomes a span, named after the node (or its type if it has
+// no name), that nests the spans of the nodes it ticked, so the leaves that dominate the time of a tick stand out.
+// The children of a ConcurrentParallel are ticked on separate goroutines and are shown on separate tracks, named
+// after their paths. Timestamps are relative to the start of the first tick.
+//
+// Parameters:
+//   - w: The writer to write the trace to.
+//   - ticks: The recorded ticks, such as those returned by ReadTrace or Recorder.Ticks.
+//
+// Returns:
+//   - An error if the trace cannot be encoded or written.
+func WriteChromeTrace(w io.Writer, ticks []TickRecord) error {
+	trace := chromeTrace{TraceEvents: []chromeTraceEvent{}, DisplayTimeUnit: "ms"}
+	var origin time.Time
+	if len(ticks) > 0 {
+		origin = ticks[0].Start
+	}
+
+	tracks := chromeTracks{ids: map[string]int{}}
+	for _, record := range ticks {
+		types := map[string]string{}
+		entered := map[string][]int{} // Indexes of the enter events not yet exited, by path
+		var spans []chromeSpan
+		for i, event := range record.Events {
+			types[event.Path] = event.Type
+			if event.Kind == "enter" {
+				entered[event.Path] = append(entered[event.Path], i)
+				continue
+			}
+			enter := i
+			if open := entered[event.Path]; len(open) > 0 {
+				enter, entered[event.Path] = open[len(open)-1], open[:len(open)-1]
+			}
+			duration := microseconds(event.Duration)
+			span := chromeTraceEvent{
+				Name:      event.Type,
+				Category:  event.Type,
+				Phase:     "X",
+				Timestamp: microseconds(event.Time.Add(-event.Duration).Sub(origin)),
+				Duration:  &duration,
+				Process:   1,
+				Thread:    tracks.id(event.Path, types),
+				Args: map[string]any{
+					"path":     event.Path,
+					"tick":     record.Tick,
+					"previous": event.Previous.String(),
+					"status":   event.Status.String(),
+				},
+			}
+			if event.Name != "" {
+				span.Name = event.Name
+			}
+			spans = append(spans, chromeSpan{enter: enter, event: span})
+		}
+		// Parents are exited after their children, so order the spans by when they were entered, parents first
+		sort.Slice(spans, func(i, j int) bool { return spans[i].enter < spans[j].enter })
+		for _, span := range spans {
+			trace.TraceEvents = append(trace.TraceEvents, span.event)
+		}
+	}
+
+	trace.TraceEvents = append(trace.TraceEvents, tracks.metadata()...)
+	return json.NewEncoder(w).Encode(trace)
+}
+
+// WriteChromeTrace writes the ticks kept in the ring buffer in the Trace Event Format (see WriteChromeTrace).
+//
+// Parameters:
+//   - w: The writer to write the trace to.
+//
+// Returns:
+//   - An error if the trace cannot be encoded or written.
+func (r *Recorder) WriteChromeTrace(w io.Writer) error {
+	return WriteChromeTrace(w, r.Ticks())
+}
+
+// chromeTracks assigns the nodes of a trace to tracks (threads in the Trace Event Format). A node is shown on the
+// track of its parent, except for the children of a ConcurrentParallel, which each get a track of their own.
+type chromeTracks struct {
+	ids   map[string]int // Track of each node, by path
+	names []string       // Path of the node that starts each track, indexed by track - 1
+}
+
+// id returns the track of the node at the given path.
+//
+// Parameters:
+//   - path: The path of the node.
+//   - types: The type of each node entered so far in the tick, by path.
+func (t *chromeTracks) id(path string, types map[string]string) int {
+	if id, ok := t.ids[path]; ok {
+		return id
+	}
+	var id int
+	i := strings.LastIndex(path, "/")
+	switch {
+	case i < 0:
+		id = t.add(path) // The root of the tree
+	case types[path[:i]] == "ConcurrentParallel":
+		id = t.add(path)
+	default:
+		id = t.id(path[:i], types)
+	}
+	t.ids[path] = id
+	return id
+}
+
+// add starts a new track at the node with the given path.
+func (t *chromeTracks) add(path string) int {
+	t.names = append(t.names, path)
+	return len(t.names)
+}
+
+// metadata returns the events naming the process and every track.
+func (t *chromeTracks) metadata() []chromeTraceEvent {
+	events := []chromeTraceEvent{
+		{Name: "process_name", Phase: "M", Process: 1, Args: map[string]any{"name": "behavior tree"}},
+	}
+	for i, path := range t.names {
+		events = append(events, chromeTraceEvent{
+			Name: "thread_name", Phase: "M", Process: 1, Thread: i + 1, Args: map[string]any{"name": path},
+		})
+	}
+	return events
+}
+
+// microseconds converts a duration to the fractional microseconds used by the Trace Event Format.
+func microseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Microsecond)
+}
@@ -0,0 +1,103 @@
+package behave
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWriteChromeTrace(t *testing.T) {
+	clock := NewFakeClock(time.Unix(100, 0))
+	bt := New(&Sequence{Name: "patrol", Children: []Node{
+		&Action{Name: "walk", Run: func() Status {
+			clock.Advance(3 * time.Millisecond)
+			return Success
+		}},
+		&ConcurrentParallel{Policy: ParallelAll, Children: []Node{
+			&Condition{Name: "left", Check: func() bool { return true }},
+			&Condition{Name: "right", Check: func() bool { return true }},
+		}},
+	}})
+	bt.Clock = clock
+	recorder := NewRecorder(RecorderOptions{})
+	recorder.Attach(bt)
+	bt.Tick()
+	clock.Advance(time.Millisecond)
+	bt.Tick()
+
+	var out bytes.Buffer
+	if err := recorder.WriteChromeTrace(&out); err != nil {
+		t.Fatalf("Recorder.WriteChromeTrace() error = %v", err)
+	}
+	var trace struct {
+		TraceEvents []struct {
+			Name  string         `json:"name"`
+			Phase string         `json:"ph"`
+			TS    float64        `json:"ts"`
+			Dur   float64        `json:"dur"`
+			TID   int            `json:"tid"`
+			Args  map[string]any `json:"args"`
+		} `json:"traceEvents"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &trace); err != nil {
+		t.Fatalf("WriteChromeTrace() wrote invalid JSON: %v", err)
+	}
+
+	type span struct {
+		Name string
+		TS   float64
+		Dur  float64
+		TID  int
+	}
+	var spans []span
+	threads := map[int]string{}
+	for _, event := range trace.TraceEvents {
+		switch event.Phase {
+		case "X":
+			if event.Args["tick"] == 1.0 {
+				spans = append(spans, span{event.Name, event.TS, event.Dur, event.TID})
+			}
+		case "M":
+			if event.Name == "thread_name" {
+				threads[event.TID] = event.Args["name"].(string)
+			}
+		}
+	}
+	want := []span{
+		{"patrol", 0, 3000, 1},
+		{"walk", 0, 3000, 1},
+		{"ConcurrentParallel", 3000, 0, 1},
+	}
+	if len(spans) != 5 || !reflect.DeepEqual(spans[:3], want) {
+		t.Fatalf("spans of tick 1 = %v, want %v followed by the conditions", spans, want)
+	}
+	if spans[3].TID == spans[4].TID || spans[3].TID == 1 || spans[4].TID == 1 {
+		t.Errorf("children of a ConcurrentParallel on tracks %d and %d, want separate tracks", spans[3].TID, spans[4].TID)
+	}
+	if threads[1] != "root" || threads[spans[3].TID] != "root/1/"+map[string]string{"left": "0", "right": "1"}[spans[3].Name] {
+		t.Errorf("track names = %v, want root and the paths of the conditions", threads)
+	}
+
+	var second []float64
+	for _, event := range trace.TraceEvents {
+		if event.Phase == "X" && event.Args["tick"] == 2.0 && event.Name == "patrol" {
+			second = append(second, event.TS)
+		}
+	}
+	if !reflect.DeepEqual(second, []float64{4000}) {
+		t.Errorf("start of tick 2 = %v, want 4000µs after the first tick", second)
+	}
+}
+
+func TestWriteChromeTrace_Empty(t *testing.T) {
+	var out bytes.Buffer
+	if err := WriteChromeTrace(&out, nil); err != nil {
+		t.Fatalf("WriteChromeTrace() error = %v", err)
+	}
+	var trace map[string]any
+	if err := json.Unmarshal(out.Bytes(), &trace); err != nil || trace["traceEvents"] == nil {
+		t.Errorf("WriteChromeTrace() = %s, want a trace with no spans", out.String())
+	}
+}
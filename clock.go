@@ -0,0 +1,111 @@
+package behave
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Clock provides the current time to time-based nodes such as WithTimeout and Retry. Replacing the real
+// clock with a FakeClock makes time-based behavior deterministic in tests.
+type Clock interface {
+	Now() time.Time // Get the current time
+}
+
+// RealClock is a Clock that reports the actual time using time.Now.
+type RealClock struct{}
+
+// Now returns the current time.
+//
+// Returns:
+//   - The result of time.Now.
+func (RealClock) Now() time.Time {
+	return time.Now()
+}
+
+// FakeClock is a Clock whose time only changes when it is advanced or set explicitly.
+// A FakeClock is safe for concurrent use.
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFakeClock creates a new FakeClock set to the given time.
+//
+// Parameters:
+//   - now: The initial time of the clock.
+//
+// Returns:
+//   - A pointer to a new FakeClock instance.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the current time of the FakeClock.
+//
+// Returns:
+//   - The time the clock was last set or advanced to.
+func (fc *FakeClock) Now() time.Time {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return fc.now
+}
+
+// Advance moves the FakeClock forward by the given duration.
+//
+// Parameters:
+//   - d: The duration to advance the clock by.
+func (fc *FakeClock) Advance(d time.Duration) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.now = fc.now.Add(d)
+}
+
+// Set sets the FakeClock to the given time.
+//
+// Parameters:
+//   - now: The new time of the clock.
+func (fc *FakeClock) Set(now time.Time) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.now = now
+}
+
+// clockKey is the context key under which a Clock is stored.
+type clockKey struct{}
+
+// WithClock returns a copy of ctx that carries the given Clock. BehaviorTree.TickContext uses it to make the
+// tree's Clock available to every time-based node in the tree.
+//
+// Parameters:
+//   - ctx: The parent context.
+//   - clock: The Clock to add to the context.
+//
+// Returns:
+//   - A new context carrying the Clock.
+func WithClock(ctx context.Context, clock Clock) context.Context {
+	return context.WithValue(ctx, clockKey{}, clock)
+}
+
+// ClockFromContext returns the Clock carried by ctx.
+//
+// Parameters:
+//   - ctx: The context to read from.
+//
+// Returns:
+//   - The Clock carried by the context, or a RealClock if there is none.
+func ClockFromContext(ctx context.Context) Clock {
+	if clock, ok := ctx.Value(clockKey{}).(Clock); ok && clock != nil {
+		return clock
+	}
+	return RealClock{}
+}
+
+// nodeClock returns the Clock a time-based node should use: its own Clock if it has one, otherwise the
+// Clock carried by the tick's context.
+func nodeClock(ctx context.Context, clock Clock) Clock {
+	if clock != nil {
+		return clock
+	}
+	return ClockFromContext(ctx)
+}
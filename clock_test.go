@@ -0,0 +1,80 @@
+package behave
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	if !clock.Now().Equal(start) {
+		t.Errorf("FakeClock.Now() = %v, want %v", clock.Now(), start)
+	}
+
+	clock.Advance(time.Minute)
+	if want := start.Add(time.Minute); !clock.Now().Equal(want) {
+		t.Errorf("FakeClock.Now() after Advance() = %v, want %v", clock.Now(), want)
+	}
+
+	clock.Set(start)
+	if !clock.Now().Equal(start) {
+		t.Errorf("FakeClock.Now() after Set() = %v, want %v", clock.Now(), start)
+	}
+}
+
+func TestClockFromContext(t *testing.T) {
+	if _, ok := ClockFromContext(context.Background()).(RealClock); !ok {
+		t.Errorf("ClockFromContext() without a clock should return a RealClock")
+	}
+
+	clock := NewFakeClock(time.Now())
+	if got := ClockFromContext(WithClock(context.Background(), clock)); got != clock {
+		t.Errorf("ClockFromContext() = %v, want the clock added by WithClock()", got)
+	}
+}
+
+func TestBehaviorTree_Clock(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	withTimeout := &WithTimeout{
+		Child:    &Action{Run: func() Status { return Running }},
+		Duration: time.Hour,
+	}
+	bt := New(&Sequence{Children: []Node{withTimeout}})
+	bt.Clock = clock
+
+	if status := bt.Tick(); status != Running {
+		t.Errorf("BehaviorTree.Tick() = %v, want %v", status, Running)
+	}
+	clock.Advance(59 * time.Minute)
+	if status := bt.Tick(); status != Running {
+		t.Errorf("BehaviorTree.Tick() before timeout = %v, want %v", status, Running)
+	}
+	clock.Advance(time.Minute)
+	if status := bt.Tick(); status != Failure {
+		t.Errorf("BehaviorTree.Tick() after timeout = %v, want %v", status, Failure)
+	}
+}
+
+func TestWithTimeout_NodeClockOverridesTreeClock(t *testing.T) {
+	treeClock := NewFakeClock(time.Now())
+	nodeClock := NewFakeClock(time.Now())
+	withTimeout := &WithTimeout{
+		Child:    &Action{Run: func() Status { return Running }},
+		Duration: time.Second,
+		Clock:    nodeClock,
+	}
+	bt := New(withTimeout)
+	bt.Clock = treeClock
+
+	bt.Tick()
+	treeClock.Advance(time.Minute)
+	if status := bt.Tick(); status != Running {
+		t.Errorf("BehaviorTree.Tick() after advancing the tree clock = %v, want %v", status, Running)
+	}
+	nodeClock.Advance(time.Second)
+	if status := bt.Tick(); status != Failure {
+		t.Errorf("BehaviorTree.Tick() after advancing the node clock = %v, want %v", status, Failure)
+	}
+}
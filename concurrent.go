@@ -0,0 +1,241 @@
+package behave
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ConcurrentParallel is a Node that ticks all its children concurrently, each on its own goroutine, and
+// decides its outcome according to Policy, exactly like Parallel. The tick only completes once every child
+// has returned from its tick, so its latency is bounded by the slowest child. With ParallelRace, the child
+// that finishes first in real time decides the outcome, and the context passed to the other children is then
+// cancelled (unless KeepRunning is set), so that children which honor their context, such as AsyncAction or
+// an Action using RunContext, return early. The context passed to the children of a race is also cancelled
+// when the tick returns, so work that outlives a tick must not depend on it. Once the outcome is decided,
+// children that are still Running are halted unless KeepRunning is set.
+//
+// Because children are ticked on separate goroutines, any state they share (other than the Blackboard,
+// which is safe for concurrent use) must be synchronized by the caller.
+type ConcurrentParallel struct {
+	Name            string // Optional name that identifies the node in String(), logs and tooling
+	Children        []Node
+	Policy          ParallelPolicy // How the outcome is decided. Defaults to ParallelThreshold
+	MinSuccessCount int            // Successes required by ParallelThreshold. If zero, one success is required
+	MinFailureCount int            // Failures that fail ParallelThreshold. If zero, fails only once MinSuccessCount is unreachable
+	KeepRunning     bool           // If true, children still Running once the outcome is decided are not halted
+	MaxConcurrency  int            // Maximum number of children ticked at the same time. If zero or negative, there is no limit
+	status          Status
+	race            context.Context    // Cancelled once the current ParallelRace is decided, or nil between races
+	cancelRace      context.CancelFunc // Cancels race
+	path            string             // Path of the node in its tree, assigned by AssignPaths
+}
+
+// Tick executes the ConcurrentParallel node with a background context.
+//
+// Returns:
+//   - The status of the ConcurrentParallel node after execution. See TickContext for details.
+func (cp *ConcurrentParallel) Tick() Status {
+	return cp.TickContext(context.Background())
+}
+
+// TickContext ticks all children concurrently and evaluates the results based on the Policy. If the configuration
+// of the node is invalid (see Validate), the node fails without ticking its children.
+//
+// Parameters:
+//   - ctx: The context for this tick. It is passed to the children of the node.
+//     If ctx is cancelled, the node fails without ticking its children.
+//
+// Returns:
+//   - The status of the ConcurrentParallel node after execution, which can be Ready, Running, Success, or Failure.
+func (cp *ConcurrentParallel) TickContext(ctx context.Context) Status {
+	if ctx.Err() != nil {
+		cp.status = Failure
+		return cp.status
+	}
+
+	if len(cp.Children) == 0 {
+		cp.status = Success
+		return cp.status
+	}
+
+	rule := parallelRule{policy: cp.Policy, minSuccessCount: cp.MinSuccessCount, minFailureCount: cp.MinFailureCount}
+	if rule.validate("ConcurrentParallel", len(cp.Children)) != nil {
+		cp.status = Failure
+		return cp.status
+	}
+
+	limit := cp.MaxConcurrency
+	if limit <= 0 || limit > len(cp.Children) {
+		limit = len(cp.Children)
+	}
+	semaphore := make(chan struct{}, limit)
+
+	// With ParallelRace, the first child to finish cancels the others, which are then halted anyway. The race
+	// context lasts for the whole race, while the context of each tick, which also carries the values of ctx, is
+	// cancelled with it or when the tick returns, so that nothing is left registered with ctx between ticks.
+	childCtx := ctx
+	cancelLosers := context.CancelFunc(func() {})
+	if cp.Policy == ParallelRace && !cp.KeepRunning {
+		if cp.race == nil {
+			cp.race, cp.cancelRace = context.WithCancel(context.Background())
+		}
+		var cancelTick context.CancelFunc
+		childCtx, cancelTick = context.WithCancel(ctx)
+		defer cancelTick()
+		defer context.AfterFunc(cp.race, cancelTick)()
+		cancelLosers = cp.cancelRace
+	}
+
+	// Tick all children, each on its own goroutine, recording the order in which they finish
+	statuses := make([]Status, len(cp.Children))
+	finished := make([]int, 0, len(cp.Children))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i, child := range cp.Children {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int, child Node) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			status := TickNode(childCtx, child)
+			mu.Lock()
+			statuses[i] = status
+			if status == Success || status == Failure {
+				finished = append(finished, i)
+				if len(finished) == 1 {
+					cancelLosers()
+				}
+			}
+			mu.Unlock()
+		}(i, child)
+	}
+	wg.Wait()
+
+	cp.status = rule.outcome(statuses, finished)
+	if cp.status != Running && !cp.KeepRunning {
+		haltRunning(cp.Children, statuses)
+		cp.endRace()
+	}
+	return cp.status
+}
+
+// endRace cancels the context of the current race, if any, and forgets it so that the next tick starts a new one.
+func (cp *ConcurrentParallel) endRace() {
+	if cp.race != nil {
+		cp.cancelRace()
+		cp.race, cp.cancelRace = nil, nil
+	}
+}
+
+// Validate checks the configuration of the ConcurrentParallel node. ConcurrentParallel never modifies its
+// configuration; an invalid configuration instead makes every tick fail.
+//
+// Returns:
+//   - nil if the configuration is valid, or an error wrapping ErrInvalidConfig describing the problem.
+func (cp *ConcurrentParallel) Validate() error {
+	rule := parallelRule{policy: cp.Policy, minSuccessCount: cp.MinSuccessCount, minFailureCount: cp.MinFailureCount}
+	return rule.validate("ConcurrentParallel", len(cp.Children))
+}
+
+// Reset resets the ConcurrentParallel node and all its children to their initial state.
+//
+// Returns:
+//   - The status of the ConcurrentParallel node after reset, which will be Ready. This method resets all child nodes
+//     to their initial state.
+func (cp *ConcurrentParallel) Reset() Status {
+	for _, child := range cp.Children {
+		child.Reset()
+	}
+	cp.endRace()
+	cp.status = Ready
+	return cp.status
+}
+
+// Halt interrupts the ConcurrentParallel node and all its children.
+//
+// Returns:
+//   - The status of the ConcurrentParallel node after halting, which will be Ready. Running child nodes are halted,
+//     while all others are reset.
+func (cp *ConcurrentParallel) Halt() Status {
+	for _, child := range cp.Children {
+		HaltNode(child)
+	}
+	cp.endRace()
+	cp.status = Ready
+	return cp.status
+}
+
+// Status returns the current status of the ConcurrentParallel node.
+//
+// Returns:
+//   - The current status of the ConcurrentParallel node, which can be Ready, Running, Success, or Failure.
+func (cp *ConcurrentParallel) Status() Status {
+	return cp.status
+}
+
+// String returns a string representation of the ConcurrentParallel node.
+//
+// Returns:
+//   - A string that represents the ConcurrentParallel node, including its current status, MinSuccessCount,
+//     MaxConcurrency, and all child nodes.
+func (cp *ConcurrentParallel) String() string {
+	var builder strings.Builder
+	builder.WriteString(nodeLabel("ConcurrentParallel", cp.Name, cp.path) + " (")
+	builder.WriteString(cp.Status().String())
+	if cp.Policy != ParallelThreshold {
+		builder.WriteString(", Policy: ")
+		builder.WriteString(cp.Policy.String())
+	}
+	builder.WriteString(", MinSuccess: ")
+	builder.WriteString(strconv.Itoa(cp.MinSuccessCount))
+	if cp.MinFailureCount > 0 {
+		builder.WriteString(", MinFailure: ")
+		builder.WriteString(strconv.Itoa(cp.MinFailureCount))
+	}
+	builder.WriteString(", MaxConcurrency: ")
+	builder.WriteString(strconv.Itoa(cp.MaxConcurrency))
+	builder.WriteString(")")
+	for _, child := range cp.Children {
+		str := child.String()
+		lines := strings.Split(str, "\n")
+		for _, line := range lines {
+			builder.WriteString("\n  ")
+			builder.WriteString(line)
+		}
+	}
+	return builder.String()
+}
+
+// NodeName returns the optional name of the ConcurrentParallel node.
+//
+// Returns:
+//   - The Name of the node, or the empty string if it has none.
+func (cp *ConcurrentParallel) NodeName() string {
+	return cp.Name
+}
+
+// Path returns the path of the ConcurrentParallel node in its tree, such as "root/0/2".
+//
+// Returns:
+//   - The path assigned by AssignPaths, or the empty string if none has been assigned.
+func (cp *ConcurrentParallel) Path() string {
+	return cp.path
+}
+
+// SetPath sets the path of the ConcurrentParallel node in its tree. It is called by AssignPaths.
+//
+// Parameters:
+//   - path: The path of the node.
+func (cp *ConcurrentParallel) SetPath(path string) {
+	cp.path = path
+}
+
+// ChildNodes returns the children of the ConcurrentParallel node.
+//
+// Returns:
+//   - The Children of the node, in order.
+func (cp *ConcurrentParallel) ChildNodes() []Node {
+	return cp.Children
+}
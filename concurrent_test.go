@@ -0,0 +1,255 @@
+package behave
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestConcurrentParallel_Tick(t *testing.T) {
+	tests := []struct {
+		name            string
+		children        []Node
+		minSuccessCount int
+		expected        Status
+	}{
+		{
+			name:            "empty",
+			children:        []Node{},
+			minSuccessCount: 1,
+			expected:        Success,
+		},
+		{
+			name: "all succeed, need all",
+			children: []Node{
+				&Action{Run: func() Status { return Success }},
+				&Action{Run: func() Status { return Success }},
+			},
+			minSuccessCount: 2,
+			expected:        Success,
+		},
+		{
+			name: "one succeeds, need two",
+			children: []Node{
+				&Action{Run: func() Status { return Success }},
+				&Action{Run: func() Status { return Failure }},
+			},
+			minSuccessCount: 2,
+			expected:        Failure,
+		},
+		{
+			name: "one running, one success, need two",
+			children: []Node{
+				&Action{Run: func() Status { return Success }},
+				&Action{Run: func() Status { return Running }},
+			},
+			minSuccessCount: 2,
+			expected:        Running,
+		},
+		{
+			name: "zero min success count, treated as one",
+			children: []Node{
+				&Action{Run: func() Status { return Failure }},
+				&Action{Run: func() Status { return Success }},
+			},
+			minSuccessCount: 0,
+			expected:        Success,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parallel := &ConcurrentParallel{Children: test.children, MinSuccessCount: test.minSuccessCount}
+			status := parallel.Tick()
+			if status != test.expected {
+				t.Errorf("ConcurrentParallel.Tick() = %v, want %v", status, test.expected)
+			}
+			if parallel.Status() != test.expected {
+				t.Errorf("ConcurrentParallel.Status() after Tick() = %v, want %v", parallel.Status(), test.expected)
+			}
+		})
+	}
+}
+
+func TestConcurrentParallel_RunsConcurrently(t *testing.T) {
+	// Each child waits until all of them have started, which only succeeds if they run at the same time
+	const childCount = 4
+	var started sync.WaitGroup
+	started.Add(childCount)
+	children := make([]Node, childCount)
+	for i := range children {
+		children[i] = &Action{Run: func() Status {
+			started.Done()
+			started.Wait()
+			return Success
+		}}
+	}
+
+	done := make(chan Status)
+	go func() {
+		done <- (&ConcurrentParallel{Children: children, MinSuccessCount: childCount}).Tick()
+	}()
+	select {
+	case status := <-done:
+		if status != Success {
+			t.Errorf("ConcurrentParallel.Tick() = %v, want %v", status, Success)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("ConcurrentParallel.Tick() did not tick its children concurrently")
+	}
+}
+
+func TestConcurrentParallel_MaxConcurrency(t *testing.T) {
+	var active, peak int32
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	children := make([]Node, 6)
+	for i := range children {
+		children[i] = &Action{Run: func() Status {
+			current := atomic.AddInt32(&active, 1)
+			for {
+				highest := atomic.LoadInt32(&peak)
+				if current <= highest || atomic.CompareAndSwapInt32(&peak, highest, current) {
+					break
+				}
+			}
+			entered <- struct{}{}
+			<-release
+			atomic.AddInt32(&active, -1)
+			return Success
+		}}
+	}
+
+	parallel := &ConcurrentParallel{Children: children, MinSuccessCount: len(children), MaxConcurrency: 2}
+	done := make(chan Status)
+	go func() { done <- parallel.Tick() }()
+	// Release the children one at a time, so that every waiting child gets the chance to start in the meantime
+	for range children {
+		<-entered
+		release <- struct{}{}
+	}
+	if status := <-done; status != Success {
+		t.Errorf("ConcurrentParallel.Tick() = %v, want %v", status, Success)
+	}
+	if peak > 2 {
+		t.Errorf("ConcurrentParallel ticked %d children at once, want at most 2", peak)
+	}
+}
+
+func TestConcurrentParallel_SharedBlackboard(t *testing.T) {
+	children := make([]Node, 8)
+	for i := range children {
+		key := strings.Repeat("k", i+1)
+		children[i] = &Action{RunWithBlackboard: func(bb *Blackboard) Status {
+			bb.Set(key, true)
+			return Success
+		}}
+	}
+	bt := New(&ConcurrentParallel{Children: children, MinSuccessCount: len(children)})
+
+	if status := bt.Tick(); status != Success {
+		t.Errorf("BehaviorTree.Tick() = %v, want %v", status, Success)
+	}
+	if bt.Blackboard.Len() != len(children) {
+		t.Errorf("Blackboard.Len() = %d, want %d", bt.Blackboard.Len(), len(children))
+	}
+}
+
+func TestConcurrentParallel_HaltAndReset(t *testing.T) {
+	halted := 0
+	parallel := &ConcurrentParallel{
+		Children: []Node{
+			&Action{Run: func() Status { return Failure }},
+			&Action{Run: func() Status { return Running }, OnHalt: func() { halted++ }},
+		},
+		MinSuccessCount: 2,
+	}
+
+	if status := parallel.Tick(); status != Failure {
+		t.Errorf("ConcurrentParallel.Tick() = %v, want %v", status, Failure)
+	}
+	if halted != 1 {
+		t.Errorf("OnHalt called %d times, want 1", halted)
+	}
+	if status := parallel.Reset(); status != Ready {
+		t.Errorf("ConcurrentParallel.Reset() = %v, want %v", status, Ready)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if status := parallel.TickContext(ctx); status != Failure {
+		t.Errorf("ConcurrentParallel.TickContext() with cancelled context = %v, want %v", status, Failure)
+	}
+}
+
+func TestConcurrentParallel_String(t *testing.T) {
+	parallel := &ConcurrentParallel{
+		Children:        []Node{&Action{}},
+		MinSuccessCount: 1,
+		MaxConcurrency:  4,
+	}
+	str := parallel.String()
+	for _, expected := range []string{"ConcurrentParallel (Ready, MinSuccess: 1, MaxConcurrency: 4)", "Action"} {
+		if !strings.Contains(str, expected) {
+			t.Errorf("ConcurrentParallel.String() should contain '%s', got %v", expected, str)
+		}
+	}
+}
+
+func TestConcurrentParallel_Policies(t *testing.T) {
+	parallel := &ConcurrentParallel{
+		Children: []Node{
+			&Action{RunContext: func(ctx context.Context) Status {
+				<-ctx.Done()
+				return Success
+			}},
+			&Action{Run: func() Status { return Failure }},
+		},
+		Policy: ParallelRace,
+	}
+	// The first child only finishes once the second one has won the race and cancelled it
+	done := make(chan Status)
+	go func() { done <- parallel.Tick() }()
+	select {
+	case status := <-done:
+		if status != Failure {
+			t.Errorf("ConcurrentParallel.Tick() with ParallelRace = %v, want %v", status, Failure)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("ConcurrentParallel.Tick() with ParallelRace did not cancel the children that lost the race")
+	}
+
+	var tickCtx context.Context
+	undecided := &ConcurrentParallel{
+		Children: []Node{&Action{RunContext: func(ctx context.Context) Status {
+			tickCtx = ctx
+			return Running
+		}}},
+		Policy: ParallelRace,
+	}
+	undecided.Tick()
+	race := undecided.race
+	for i := 0; i < 100; i++ {
+		if status := undecided.Tick(); status != Running {
+			t.Fatalf("ConcurrentParallel.Tick() with ParallelRace = %v, want %v", status, Running)
+		}
+	}
+	if undecided.race != race || race.Err() != nil || tickCtx.Err() == nil {
+		t.Errorf("an undecided race should keep a single live race context and release the context of every tick")
+	}
+	undecided.Halt()
+	if race.Err() == nil || undecided.race != nil {
+		t.Errorf("ConcurrentParallel.Halt() did not cancel and forget the context of an undecided race")
+	}
+
+	invalid := &ConcurrentParallel{Children: []Node{&Action{}}, MinSuccessCount: 3}
+	if err := invalid.Validate(); err == nil {
+		t.Errorf("ConcurrentParallel.Validate() = nil, want an error")
+	}
+	if status := invalid.Tick(); status != Failure {
+		t.Errorf("ConcurrentParallel.Tick() with invalid configuration = %v, want %v", status, Failure)
+	}
+}
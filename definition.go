@@ -0,0 +1,445 @@
+package behave
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// ErrUnknownNode is returned, wrapped with details, when a tree definition refers to a node type or leaf
+// name that is not known, or when a node cannot be described by a Definition.
+var ErrUnknownNode = errors.New("behave: unknown node")
+
+// Definition describes a node, and through its children the whole subtree below it, as plain data. It is the
+// model read and written by the tree file formats, so that trees can be edited without recompiling.
+//
+// Type is the name of the node's type, such as "Sequence" or "WithTimeout". Name is the optional name of the
+// node. Leaf nodes (Action, Condition and AsyncAction) hold Go functions, so they are referred to by Name and
+// looked up when the tree is built.
+// Params holds the configuration of the node keyed by field name, such as "MinSuccessCount", "MaxCount" or
+// "Duration"; durations are written as strings such as "1.5s". For a Composite node, Conditions holds its
+// conditions and Children holds its single child.
+type Definition struct {
+	Type       string         `json:"type" yaml:"type"`
+	Name       string         `json:"name,omitempty" yaml:"name,omitempty"`
+	Params     map[string]any `json:"params,omitempty" yaml:"params,omitempty"`
+	Conditions []*Definition  `json:"conditions,omitempty" yaml:"conditions,omitempty"`
+	Children   []*Definition  `json:"children,omitempty" yaml:"children,omitempty"`
+}
+
+// Describer is implemented by custom node types that can describe themselves, so that trees containing them
+// can be saved and loaded back with a Registry that knows the type. Describe uses it for every node that is
+// not of a built-in type, and describes the node's children itself (see Parent).
+type Describer interface {
+	DescribeNode() (*Definition, error) // Describe the type, name and parameters of the node, without its children
+}
+
+// Describe returns the Definition of a node and all the nodes below it.
+//
+// Fields that hold Go values rather than configuration have no form in a Definition and are not described:
+// Retry.Retryable, the Clock of Retry and WithTimeout, and the Logger and Context of Log. A tree built from the
+// Definition uses their defaults, so set them again after loading a tree that needs them.
+//
+// Parameters:
+//   - node: The root of the subtree to describe.
+//
+// Returns:
+//   - The Definition of the node.
+//   - An error wrapping ErrUnknownNode if the subtree contains a node type that is neither built in nor a
+//     Describer, or ErrInvalidConfig if it contains a leaf node without a Name.
+func Describe(node Node) (*Definition, error) {
+	return describe(node, "root")
+}
+
+// describe returns the Definition of the node at the given path.
+func describe(node Node, path string) (*Definition, error) {
+	if node == nil {
+		return nil, fmt.Errorf("%s: %w: node is nil", path, ErrInvalidConfig)
+	}
+	def, err := describeNode(node)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if isLeafKind(def.Type) && def.Name == "" {
+		return nil, fmt.Errorf("%s: %w: %s has no Name", path, ErrInvalidConfig, def.Type)
+	}
+
+	// Describe the children, numbering them the same way as Walk
+	var conditions []Node
+	if composite, ok := node.(*Composite); ok {
+		conditions = composite.Conditions
+	}
+	for i, child := range childNodes(node) {
+		if child == nil {
+			continue
+		}
+		childDef, err := describe(child, path+"/"+strconv.Itoa(i))
+		if err != nil {
+			return nil, err
+		}
+		if i < len(conditions) {
+			def.Conditions = append(def.Conditions, childDef)
+		} else {
+			def.Children = append(def.Children, childDef)
+		}
+	}
+	return def, nil
+}
+
+// describeNode returns the Definition of a node without its children.
+func describeNode(node Node) (*Definition, error) {
+	def := &Definition{Params: map[string]any{}}
+	switch n := node.(type) {
+	case *Action:
+		def.Type = "Action"
+	case *Condition:
+		def.Type = "Condition"
+	case *AsyncAction:
+		def.Type = "AsyncAction"
+	case *Composite:
+		def.Type = "Composite"
+	case *Selector:
+		def.Type = "Selector"
+	case *Sequence:
+		def.Type = "Sequence"
+	case *MemorySelector:
+		def.Type = "MemorySelector"
+	case *ReactiveSequence:
+		def.Type = "ReactiveSequence"
+	case *ReactiveSelector:
+		def.Type = "ReactiveSelector"
+	case *Parallel:
+		def.Type = "Parallel"
+		describeParallel(def.Params, n.Policy, n.MinSuccessCount, n.MinFailureCount, n.KeepRunning)
+	case *ConcurrentParallel:
+		def.Type = "ConcurrentParallel"
+		describeParallel(def.Params, n.Policy, n.MinSuccessCount, n.MinFailureCount, n.KeepRunning)
+		if n.MaxConcurrency > 0 {
+			def.Params["MaxConcurrency"] = n.MaxConcurrency
+		}
+	case *Retry:
+		def.Type = "Retry"
+		if n.MaxAttempts > 0 {
+			def.Params["MaxAttempts"] = n.MaxAttempts
+		}
+		if n.Backoff != nil {
+			backoff, err := describeBackoff(n.Backoff)
+			if err != nil {
+				return nil, err
+			}
+			def.Params["Backoff"] = backoff
+		}
+	case *Repeat:
+		def.Type = "Repeat"
+	case *Invert:
+		def.Type = "Invert"
+	case *AlwaysSuccess:
+		def.Type = "AlwaysSuccess"
+	case *AlwaysFailure:
+		def.Type = "AlwaysFailure"
+	case *RepeatN:
+		def.Type = "RepeatN"
+		def.Params["MaxCount"] = n.MaxCount
+	case *Forever:
+		def.Type = "Forever"
+	case *WhileSuccess:
+		def.Type = "WhileSuccess"
+	case *WhileFailure:
+		def.Type = "WhileFailure"
+	case *WithTimeout:
+		def.Type = "WithTimeout"
+		def.Params["Duration"] = n.Duration.String()
+	case *Log:
+		def.Type = "Log"
+		if n.Message != "" {
+			def.Params["Message"] = n.Message
+		}
+		if n.LogLevel != nil {
+			def.Params["LogLevel"] = n.LogLevel.String()
+		}
+	default:
+		return describeCustom(node)
+	}
+	def.Name = NameOf(node)
+	if len(def.Params) == 0 {
+		def.Params = nil
+	}
+	return def, nil
+}
+
+// describeCustom returns the Definition of a node that is not of a built-in type, without its children.
+func describeCustom(node Node) (*Definition, error) {
+	describer, ok := node.(Describer)
+	if !ok {
+		return nil, fmt.Errorf("%w: cannot describe node of type %T", ErrUnknownNode, node)
+	}
+	described, err := describer.DescribeNode()
+	if err != nil {
+		return nil, err
+	}
+	if described == nil || described.Type == "" {
+		return nil, fmt.Errorf("%w: %T described itself without a type", ErrInvalidConfig, node)
+	}
+	def := &Definition{Type: described.Type, Name: described.Name, Params: described.Params}
+	if def.Name == "" {
+		def.Name = NameOf(node)
+	}
+	if len(def.Params) == 0 {
+		def.Params = nil
+	}
+	return def, nil
+}
+
+// describeParallel adds the parameters shared by Parallel and ConcurrentParallel to params.
+func describeParallel(params map[string]any, policy ParallelPolicy, minSuccessCount, minFailureCount int, keepRunning bool) {
+	if policy != ParallelThreshold {
+		params["Policy"] = policy.String()
+	}
+	params["MinSuccessCount"] = minSuccessCount
+	if minFailureCount > 0 {
+		params["MinFailureCount"] = minFailureCount
+	}
+	if keepRunning {
+		params["KeepRunning"] = true
+	}
+}
+
+// describeBackoff returns the parameters of one of the built-in Backoff implementations.
+func describeBackoff(backoff Backoff) (map[string]any, error) {
+	switch b := backoff.(type) {
+	case FixedBackoff:
+		return map[string]any{"Type": "Fixed", "Interval": b.Interval.String()}, nil
+	case ExponentialBackoff:
+		params := map[string]any{"Type": "Exponential", "Initial": b.Initial.String()}
+		if b.Max > 0 {
+			params["Max"] = b.Max.String()
+		}
+		if b.Multiplier != 0 {
+			params["Multiplier"] = b.Multiplier
+		}
+		return params, nil
+	case JitteredBackoff:
+		params := map[string]any{"Type": "Jittered", "Jitter": b.Jitter}
+		if b.Backoff != nil {
+			inner, err := describeBackoff(b.Backoff)
+			if err != nil {
+				return nil, err
+			}
+			params["Backoff"] = inner
+		}
+		return params, nil
+	default:
+		return nil, fmt.Errorf("%w: cannot describe backoff of type %T", ErrUnknownNode, backoff)
+	}
+}
+
+// params reads the parameters of a Definition, converting them from the types produced by the different
+// file formats. The first conversion error is kept in err, and the keys that were read are remembered so
+// that done can report parameters that no node uses.
+type params struct {
+	values map[string]any
+	used   map[string]bool
+	err    error
+}
+
+// get returns the raw value of a parameter, and whether it is set.
+func (p *params) get(key string) (any, bool) {
+	if p.used == nil {
+		p.used = map[string]bool{}
+	}
+	p.used[key] = true
+	value, ok := p.values[key]
+	return value, ok && value != nil
+}
+
+// fail records a conversion error for a parameter, unless an earlier error was already recorded.
+func (p *params) fail(key string, value any, want string) {
+	if p.err == nil {
+		p.err = fmt.Errorf("%w: parameter %s must be %s, got %v", ErrInvalidConfig, key, want, value)
+	}
+}
+
+// done reports the first conversion error, or an error naming a parameter that was never read.
+func (p *params) done() error {
+	if p.err != nil {
+		return p.err
+	}
+	var unknown []string
+	for key := range p.values {
+		if !p.used[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("%w: unknown parameter %s", ErrInvalidConfig, unknown[0])
+	}
+	return nil
+}
+
+// int returns an integer parameter, or zero if it is not set.
+func (p *params) int(key string) int {
+	value, ok := p.get(key)
+	if !ok {
+		return 0
+	}
+	switch v := value.(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case uint64:
+		return int(v)
+	case float64:
+		if v == math.Trunc(v) {
+			return int(v)
+		}
+	case string:
+		if i, err := strconv.Atoi(v); err == nil {
+			return i
+		}
+	}
+	p.fail(key, value, "an integer")
+	return 0
+}
+
+// float returns a floating-point parameter, or zero if it is not set.
+func (p *params) float(key string) float64 {
+	value, ok := p.get(key)
+	if !ok {
+		return 0
+	}
+	switch v := value.(type) {
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case string:
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
+		}
+	}
+	p.fail(key, value, "a number")
+	return 0
+}
+
+// bool returns a boolean parameter, or false if it is not set.
+func (p *params) bool(key string) bool {
+	value, ok := p.get(key)
+	if !ok {
+		return false
+	}
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	p.fail(key, value, "a boolean")
+	return false
+}
+
+// string returns a string parameter, or the empty string if it is not set.
+func (p *params) string(key string) string {
+	value, ok := p.get(key)
+	if !ok {
+		return ""
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	p.fail(key, value, "a string")
+	return ""
+}
+
+// duration returns a duration parameter written like "1.5s", or zero if it is not set.
+func (p *params) duration(key string) time.Duration {
+	value, ok := p.get(key)
+	if !ok {
+		return 0
+	}
+	switch v := value.(type) {
+	case time.Duration:
+		return v
+	case string:
+		if d, err := time.ParseDuration(v); err == nil {
+			return d
+		}
+	}
+	p.fail(key, value, "a duration such as \"1.5s\"")
+	return 0
+}
+
+// policy returns a ParallelPolicy parameter written by its name, or ParallelThreshold if it is not set.
+func (p *params) policy(key string) ParallelPolicy {
+	value, ok := p.get(key)
+	if !ok {
+		return ParallelThreshold
+	}
+	for _, policy := range []ParallelPolicy{ParallelThreshold, ParallelAll, ParallelAny, ParallelRace} {
+		if value == policy.String() {
+			return policy
+		}
+	}
+	p.fail(key, value, "one of Threshold, All, Any or Race")
+	return ParallelThreshold
+}
+
+// level returns a log level parameter written like "INFO", or nil if it is not set.
+func (p *params) level(key string) *slog.Level {
+	value, ok := p.get(key)
+	if !ok {
+		return nil
+	}
+	if s, ok := value.(string); ok {
+		var level slog.Level
+		if err := level.UnmarshalText([]byte(s)); err == nil {
+			return &level
+		}
+	}
+	p.fail(key, value, "a log level such as \"INFO\"")
+	return nil
+}
+
+// backoff returns a Backoff parameter, or nil if it is not set. The parameter is itself a set of parameters
+// whose Type is Fixed, Exponential or Jittered, and whose other keys are the fields of that Backoff.
+func (p *params) backoff(key string) Backoff {
+	value, ok := p.get(key)
+	if !ok {
+		return nil
+	}
+	values, ok := value.(map[string]any)
+	if !ok {
+		p.fail(key, value, "a set of parameters")
+		return nil
+	}
+
+	inner := &params{values: values}
+	var backoff Backoff
+	switch kind := inner.string("Type"); kind {
+	case "Fixed":
+		backoff = FixedBackoff{Interval: inner.duration("Interval")}
+	case "Exponential":
+		backoff = ExponentialBackoff{
+			Initial:    inner.duration("Initial"),
+			Max:        inner.duration("Max"),
+			Multiplier: inner.float("Multiplier"),
+		}
+	case "Jittered":
+		backoff = JitteredBackoff{Backoff: inner.backoff("Backoff"), Jitter: inner.float("Jitter")}
+	default:
+		inner.fail("Type", kind, "one of Fixed, Exponential or Jittered")
+	}
+	if err := inner.done(); err != nil && p.err == nil {
+		p.err = fmt.Errorf("parameter %s: %w", key, err)
+	}
+	return backoff
+}
@@ -0,0 +1,140 @@
+package behave
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// statusColors holds the color the diagram exporters fill a node with for each Status.
+var statusColors = map[Status]string{
+	Ready:   "#eeeeee",
+	Running: "#fff3b0",
+	Success: "#b7e4c7",
+	Failure: "#f4a6a6",
+}
+
+// DiagramOptions controls what the Mermaid and PlantUML renderers include in a diagram. Rendering a diagram
+// never checks a Condition, so with Status set, conditions are drawn Ready.
+type DiagramOptions struct {
+	Status bool // If true, every node is annotated with its current Status and filled with a color for it
+}
+
+// diagramNode is a node of a tree as drawn by the diagram exporters.
+type diagramNode struct {
+	id       string   // Identifier of the node within the diagram, such as "n3"
+	title    string   // Type of the node, followed by its name if it has one
+	params   []string // Parameters of the node, formatted as "Key: value" and sorted by key
+	status   Status
+	check    bool          // Whether the node is a Condition, which diagrams draw differently
+	children []diagramEdge // Edges to the children of the node, in order
+}
+
+// diagramEdge is an edge from a node to one of its children.
+type diagramEdge struct {
+	label string // Label of the edge, such as "condition", or empty if the edge is not labeled
+	node  *diagramNode
+}
+
+// newDiagram returns the diagram of the tree below root. Nodes are numbered in depth-first order.
+func newDiagram(root Node) *diagramNode {
+	count := 0
+	var visit func(node Node) *diagramNode
+	visit = func(node Node) *diagramNode {
+		d := &diagramNode{id: "n" + strconv.Itoa(count), status: idleStatus(node)}
+		count++
+
+		def, err := describeNode(node)
+		if err != nil {
+			// A custom node type, or a built-in one with a custom Backoff, is drawn by its type alone
+			d.title = typeName(node)
+		} else {
+			d.title = def.Type
+			if def.Name != "" {
+				d.title += ": " + def.Name
+			}
+			d.params = formatParams(def.Params)
+			d.check = def.Type == "Condition"
+		}
+
+		// The conditions of a Composite come before its child
+		conditions := 0
+		if composite, ok := node.(*Composite); ok {
+			conditions = len(composite.Conditions)
+		}
+		for i, child := range childNodes(node) {
+			if child == nil {
+				continue
+			}
+			edge := diagramEdge{node: visit(child)}
+			if conditions > 0 {
+				edge.label = "child"
+				if i < conditions {
+					edge.label = "condition"
+				}
+			}
+			d.children = append(d.children, edge)
+		}
+		return d
+	}
+	return visit(root)
+}
+
+// nodes returns the node and all the nodes below it in depth-first order.
+func (d *diagramNode) nodes() []*diagramNode {
+	nodes := []*diagramNode{d}
+	d.walk(func(parent *diagramNode, edge diagramEdge) {
+		nodes = append(nodes, edge.node)
+	})
+	return nodes
+}
+
+// walk calls visit for every node of the diagram in depth-first order, passing the edge that leads to it.
+func (d *diagramNode) walk(visit func(parent *diagramNode, edge diagramEdge)) {
+	for _, edge := range d.children {
+		visit(d, edge)
+		edge.node.walk(visit)
+	}
+}
+
+// lines returns the lines of the label of the node: its title, its parameters and, if withStatus is set, its
+// Status.
+func (d *diagramNode) lines(withStatus bool) []string {
+	lines := append([]string{d.title}, d.params...)
+	if withStatus {
+		lines = append(lines, d.status.String())
+	}
+	return lines
+}
+
+// formatParams formats the parameters of a node as "Key: value", sorted by key.
+func formatParams(params map[string]any) []string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	lines := make([]string, 0, len(keys))
+	for _, key := range keys {
+		lines = append(lines, key+": "+formatParam(params[key]))
+	}
+	return lines
+}
+
+// formatParam formats a parameter value. Nested parameters, such as a Backoff, are written as
+// Type(Key: value, ...).
+func formatParam(value any) string {
+	nested, ok := value.(map[string]any)
+	if !ok {
+		return fmt.Sprint(value)
+	}
+	kind, _ := nested["Type"].(string)
+	var fields []string
+	for _, line := range formatParams(nested) {
+		if !strings.HasPrefix(line, "Type: ") {
+			fields = append(fields, line)
+		}
+	}
+	return kind + "(" + strings.Join(fields, ", ") + ")"
+}
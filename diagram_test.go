@@ -0,0 +1,95 @@
+package behave
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// diagramTestTree returns a small tree that has been ticked once, for the diagram tests, and the number of times
+// its condition has been checked.
+func diagramTestTree() (*BehaviorTree, *int) {
+	checks := 0
+	bt := New(&Selector{Children: []Node{
+		&Composite{
+			Conditions: []Node{&Condition{Name: "DoorOpen", Check: func() bool {
+				checks++
+				return false
+			}}},
+			Child: &Action{Name: "Enter"},
+		},
+		&WithTimeout{Duration: time.Second, Child: &Action{Name: `Say "hi"`, Run: func() Status { return Running }}},
+	}})
+	bt.Tick()
+	return bt, &checks
+}
+
+func TestMermaid(t *testing.T) {
+	bt, checks := diagramTestTree()
+
+	plain := bt.Mermaid(DiagramOptions{})
+	for _, expected := range []string{
+		"flowchart TD\n",
+		`  n0("Selector")`,
+		`  n2(["Condition: DoorOpen"])`,
+		`  n4("WithTimeout<br/>Duration: 1s")`,
+		`  n5("Action: Say #quot;hi#quot;")`,
+		"  n0 --> n1\n",
+		"  n1 -->|condition| n2\n",
+		"  n1 -->|child| n3\n",
+		"  n4 --> n5\n",
+	} {
+		if !strings.Contains(plain, expected) {
+			t.Errorf("BehaviorTree.Mermaid() should contain %q, got\n%s", expected, plain)
+		}
+	}
+	if strings.Contains(plain, "Running") || strings.Contains(plain, "classDef") {
+		t.Errorf("BehaviorTree.Mermaid() without Status should not annotate statuses, got\n%s", plain)
+	}
+
+	annotated := bt.Mermaid(DiagramOptions{Status: true})
+	for _, expected := range []string{
+		`  n0("Selector<br/>Running")`,
+		`  n2(["Condition: DoorOpen<br/>Ready"])`,
+		"  classDef running fill:#fff3b0\n",
+		"  class n0,n4,n5 running\n",
+		"  class n1 failure\n",
+		"  class n2,n3 ready\n",
+	} {
+		if !strings.Contains(annotated, expected) {
+			t.Errorf("BehaviorTree.Mermaid() with Status should contain %q, got\n%s", expected, annotated)
+		}
+	}
+	if *checks != 1 {
+		t.Errorf("the condition was checked %d times, want only once by the tick and never by the diagrams", *checks)
+	}
+}
+
+func TestPlantUML(t *testing.T) {
+	bt, _ := diagramTestTree()
+
+	plain := bt.PlantUML(DiagramOptions{})
+	for _, expected := range []string{
+		"@startuml\n",
+		`rectangle "Selector" as n0` + "\n",
+		`usecase "Condition: DoorOpen" as n2` + "\n",
+		`rectangle "WithTimeout\nDuration: 1s" as n4` + "\n",
+		`rectangle "Action: Say 'hi'" as n5` + "\n",
+		"n1 --> n2 : condition\n",
+		"n1 --> n3 : child\n",
+		"n4 --> n5\n",
+		"@enduml\n",
+	} {
+		if !strings.Contains(plain, expected) {
+			t.Errorf("BehaviorTree.PlantUML() should contain %q, got\n%s", expected, plain)
+		}
+	}
+
+	annotated := bt.PlantUML(DiagramOptions{Status: true})
+	if expected := `usecase "Condition: DoorOpen\nReady" as n2 #eeeeee`; !strings.Contains(annotated, expected) {
+		t.Errorf("BehaviorTree.PlantUML() with Status should contain %q, got\n%s", expected, annotated)
+	}
+	if empty := PlantUML(nil, DiagramOptions{}); empty != "@startuml\n@enduml\n" {
+		t.Errorf("PlantUML(nil) = %q, want an empty diagram", empty)
+	}
+}
@@ -0,0 +1,65 @@
+package behave
+
+import (
+	"strings"
+)
+
+// DOT returns a Graphviz DOT diagram of the node and all the nodes below it. Each node is labeled with its
+// type, name, parameters and current Status, and filled with a color that depends on its Status, so a
+// diagram taken while the tree runs shows which branches are Running, have succeeded or have failed.
+// Conditions are drawn as ellipses and all other nodes as boxes, and are never checked to draw them, so they are
+// labeled Ready. The edges from a Composite node are labeled "condition" or "child".
+//
+// Parameters:
+//   - node: The root of the subtree to draw.
+//
+// Returns:
+//   - The DOT source of the diagram, which can be rendered with Graphviz, for example with "dot -Tsvg".
+func DOT(node Node) string {
+	var builder strings.Builder
+	builder.WriteString("digraph BehaviorTree {\n")
+	builder.WriteString("  node [shape=box, style=\"rounded,filled\", fontname=\"Helvetica\"];\n")
+	if node == nil {
+		builder.WriteString("}\n")
+		return builder.String()
+	}
+
+	root := newDiagram(node)
+	for _, d := range root.nodes() {
+		writeDOTNode(&builder, d)
+	}
+	root.walk(func(parent *diagramNode, edge diagramEdge) {
+		builder.WriteString("  " + parent.id + " -> " + edge.node.id)
+		if edge.label != "" {
+			builder.WriteString(" [label=" + dotQuote(edge.label) + "]")
+		}
+		builder.WriteString(";\n")
+	})
+	builder.WriteString("}\n")
+	return builder.String()
+}
+
+// DOT returns a Graphviz DOT diagram of the BehaviorTree. See DOT for details.
+//
+// Returns:
+//   - The DOT source of the diagram of the tree's Root.
+func (bt *BehaviorTree) DOT() string {
+	return DOT(bt.Root)
+}
+
+// writeDOTNode writes the statement that declares a node of a DOT diagram.
+func writeDOTNode(builder *strings.Builder, d *diagramNode) {
+	builder.WriteString("  " + d.id + " [label=" + dotQuote(strings.Join(d.lines(true), "\n")))
+	if d.check {
+		builder.WriteString(", shape=ellipse")
+	}
+	builder.WriteString(", fillcolor=" + dotQuote(statusColors[d.status]) + "];\n")
+}
+
+// dotQuote returns s as a quoted DOT string, escaping quotes and backslashes and writing line breaks as \n.
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
@@ -0,0 +1,51 @@
+package behave
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDOT(t *testing.T) {
+	bt := New(&Sequence{Children: []Node{
+		&Composite{
+			Conditions: []Node{&Condition{Name: "DoorOpen", Check: func() bool { return true }}},
+			Child:      &RepeatN{MaxCount: 3, Child: &Action{Name: "Step", Run: func() Status { return Success }}},
+		},
+		&WithTimeout{Duration: 2 * time.Second, Child: &Action{Run: func() Status { return Running }}},
+		&Retry{Backoff: ExponentialBackoff{Initial: time.Millisecond, Max: time.Second}, Child: &testNode{statusFunc: func() Status { return Ready }}},
+	}})
+	bt.Tick()
+
+	dot := bt.DOT()
+	for _, expected := range []string{
+		"digraph BehaviorTree {\n",
+		`n0 [label="Sequence\nRunning", fillcolor="#fff3b0"];`,
+		`n2 [label="Condition: DoorOpen\nReady", shape=ellipse, fillcolor="#eeeeee"];`,
+		`n3 [label="RepeatN\nMaxCount: 3\nRunning", fillcolor="#fff3b0"];`,
+		`n5 [label="WithTimeout\nDuration: 2s\nReady", fillcolor="#eeeeee"];`,
+		`label="Retry\nBackoff: Exponential(Initial: 1ms, Max: 1s)\nReady"`,
+		`label="testNode\nReady"`,
+		"n0 -> n1;\n",
+		`n1 -> n2 [label="condition"];`,
+		`n1 -> n3 [label="child"];`,
+		"n3 -> n4;\n",
+	} {
+		if !strings.Contains(dot, expected) {
+			t.Errorf("BehaviorTree.DOT() should contain %q, got\n%s", expected, dot)
+		}
+	}
+	if !strings.HasSuffix(dot, "}\n") {
+		t.Errorf("BehaviorTree.DOT() should end with the closing brace, got\n%s", dot)
+	}
+}
+
+func TestDOT_Escaping(t *testing.T) {
+	dot := DOT(&Log{Message: `say "hi" \ bye`, Child: &Action{Name: "Greet"}})
+	if !strings.Contains(dot, `Message: say \"hi\" \\ bye`) {
+		t.Errorf("DOT() should escape quotes and backslashes, got\n%s", dot)
+	}
+	if empty := DOT(nil); empty != "digraph BehaviorTree {\n  node [shape=box, style=\"rounded,filled\", fontname=\"Helvetica\"];\n}\n" {
+		t.Errorf("DOT(nil) = %q, want an empty graph", empty)
+	}
+}
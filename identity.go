@@ -0,0 +1,88 @@
+package behave
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// Identifiable is implemented by nodes that have an optional name and a path locating them in their tree, such as
+// "root/0/2" for the third child of the first child of the root node. All built-in nodes implement Identifiable.
+// The paths are assigned by AssignPaths, which BehaviorTree calls when it is created, when its Root changes and
+// when it is refreshed (see BehaviorTree.Refresh), so a node keeps its path for as long as the structure of the
+// tree doesn't change.
+type Identifiable interface {
+	NodeName() string    // Returns the optional name of the node, or the empty string if it has none
+	Path() string        // Returns the path of the node, or the empty string if none has been assigned
+	SetPath(path string) // Sets the path of the node
+}
+
+// AssignPaths assigns a path to the node and every node below it that implements Identifiable. The node is
+// given the path "root", and every child the path of its parent followed by its index, in the order in which
+// the children are ticked. The conditions of a Composite node are numbered before its child, the same way as
+// in tree definitions and their errors.
+//
+// Parameters:
+//   - root: The root of the subtree whose paths are assigned.
+func AssignPaths(root Node) {
+	// Paths are only set when they change, so that nodes running in other goroutines don't see a write on
+	// every tick
+	_ = Walk(root, PreOrder, func(node Node, depth int, path string) error {
+		if n, ok := node.(Identifiable); ok && n.Path() != path {
+			n.SetPath(path)
+		}
+		return nil
+	})
+}
+
+// NameOf returns the name of a node.
+//
+// Parameters:
+//   - node: The node whose name is returned.
+//
+// Returns:
+//   - The name of the node, or the empty string if it has none or doesn't implement Identifiable.
+func NameOf(node Node) string {
+	if n, ok := node.(Identifiable); ok {
+		return n.NodeName()
+	}
+	return ""
+}
+
+// PathOf returns the path of a node in its tree.
+//
+// Parameters:
+//   - node: The node whose path is returned.
+//
+// Returns:
+//   - The path of the node, such as "root/0/2", or the empty string if none has been assigned or the node
+//     doesn't implement Identifiable.
+func PathOf(node Node) string {
+	if n, ok := node.(Identifiable); ok {
+		return n.Path()
+	}
+	return ""
+}
+
+// nodeLabel returns the label that starts the String of a node: its type, followed by its quoted name and its
+// path in brackets when they are set, such as `Action "OpenDoor" [root/0/2]`.
+func nodeLabel(kind, name, path string) string {
+	label := kind
+	if name != "" {
+		label += " " + strconv.Quote(name)
+	}
+	if path != "" {
+		label += " [" + path + "]"
+	}
+	return label
+}
+
+// typeName returns the name of the type of a node, such as "Sequence", without the package name or pointer. A nil
+// node, or a nil pointer to one, is named by its Go type instead, such as "*behave.Action".
+func typeName(node Node) string {
+	value := reflect.Indirect(reflect.ValueOf(node))
+	if !value.IsValid() {
+		return fmt.Sprintf("%T", node)
+	}
+	return value.Type().Name()
+}
@@ -0,0 +1,136 @@
+package behave
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestAssignPaths(t *testing.T) {
+	check := &Condition{Name: "DoorOpen", Check: func() bool { return true }}
+	enter := &Action{Name: "Enter", Run: func() Status { return Success }}
+	retry := &Retry{Name: "retry", Child: &Action{Run: func() Status { return Failure }}}
+	sequence := &Sequence{Children: []Node{
+		&Composite{Conditions: []Node{check}, Child: enter},
+		retry,
+		&testNode{statusFunc: func() Status { return Success }},
+	}}
+	bt := New(sequence)
+
+	tests := []struct {
+		node Node
+		path string
+	}{
+		{sequence, "root"},
+		{sequence.Children[0], "root/0"},
+		{check, "root/0/0"},
+		{enter, "root/0/1"},
+		{retry, "root/1"},
+		{retry.Child, "root/1/0"},
+		{sequence.Children[2], ""},
+	}
+	for _, test := range tests {
+		if path := PathOf(test.node); path != test.path {
+			t.Errorf("PathOf(%T) = %q, want %q", test.node, path, test.path)
+		}
+	}
+	if name := NameOf(retry); name != "retry" {
+		t.Errorf("NameOf(Retry) = %q, want %q", name, "retry")
+	}
+	if name := NameOf(sequence.Children[2]); name != "" {
+		t.Errorf("NameOf(testNode) = %q, want the empty string", name)
+	}
+
+	// Nodes added to the tree get their paths once it is refreshed
+	added := &Action{Run: func() Status { return Success }}
+	sequence.Children = append([]Node{added}, sequence.Children...)
+	bt.Tick()
+	if path := PathOf(added); path != "" {
+		t.Errorf("PathOf(added Action) before Refresh = %q, want the empty string", path)
+	}
+	bt.Refresh().Tick()
+	if path := PathOf(added); path != "root/0" {
+		t.Errorf("PathOf(added Action) = %q, want %q", path, "root/0")
+	}
+	if path := PathOf(retry); path != "root/2" {
+		t.Errorf("PathOf(Retry) after adding a child = %q, want %q", path, "root/2")
+	}
+
+	// A new root is refreshed on the next tick
+	bt.Root = &Invert{Child: sequence}
+	bt.Tick()
+	if path := PathOf(retry); path != "root/0/2" {
+		t.Errorf("PathOf(Retry) after replacing the root = %q, want %q", path, "root/0/2")
+	}
+}
+
+func TestBehaviorTree_String_NamesAndPaths(t *testing.T) {
+	bt := New(&Selector{Name: "patrol", Children: []Node{
+		&Action{Name: "OpenDoor", Run: func() Status { return Running }},
+		&RepeatN{MaxCount: 2, Child: &Action{}},
+	}})
+	bt.Tick()
+
+	str := bt.String()
+	for _, expected := range []string{
+		`Selector "patrol" [root] (Running)`,
+		`Action "OpenDoor" [root/0] (Running)`,
+		`RepeatN [root/1] (Ready`,
+		`Action [root/1/0] (Ready)`,
+	} {
+		if !strings.Contains(str, expected) {
+			t.Errorf("BehaviorTree.String() should contain %q, got\n%s", expected, str)
+		}
+	}
+
+	// Nodes outside a tree keep the plain format
+	if str := (&Action{}).String(); str != "Action (Ready)" {
+		t.Errorf("Action.String() = %q, want %q", str, "Action (Ready)")
+	}
+}
+
+func TestLog_NamesAndPaths(t *testing.T) {
+	var buf bytes.Buffer
+	origHandler := slog.Default().Handler()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, nil)))
+	defer slog.SetDefault(slog.New(origHandler))
+
+	New(&Sequence{Children: []Node{
+		&Log{Name: "audit", Message: "opened", Child: &Action{Name: "OpenDoor", Run: func() Status { return Success }}},
+	}}).Tick()
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("decoding log record %q: %v", buf.String(), err)
+	}
+	for key, want := range map[string]string{
+		"child_type": "Action",
+		"child_name": "OpenDoor",
+		"child_path": "root/0/0",
+		"name":       "audit",
+		"path":       "root/0",
+	} {
+		if got := record[key]; got != want {
+			t.Errorf("log attribute %s = %v, want %q", key, got, want)
+		}
+	}
+}
+
+func TestTypeName(t *testing.T) {
+	var action *Action
+	tests := []struct {
+		node Node
+		want string
+	}{
+		{&Sequence{}, "Sequence"},
+		{action, "*behave.Action"},
+		{nil, "<nil>"},
+	}
+	for _, test := range tests {
+		if got := typeName(test.node); got != test.want {
+			t.Errorf("typeName(%#v) = %q, want %q", test.node, got, test.want)
+		}
+	}
+}
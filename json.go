@@ -0,0 +1,63 @@
+package behave
+
+import (
+	"encoding/json"
+)
+
+// LoadJSON creates a BehaviorTree from the JSON encoding of the Definition of its root node, using the built-in
+// node types and the given leaves. Use LoadJSONWith to load trees that use custom node types.
+//
+// Parameters:
+//   - data: The JSON document, as written by BehaviorTree.MarshalJSON.
+//   - leaves: Functions that create the leaf nodes, keyed by the Name the document refers to them by.
+//
+// Returns:
+//   - A pointer to a new BehaviorTree instance.
+//   - An error if the document is not valid JSON or the tree cannot be built (see Definition.Build).
+func LoadJSON(data []byte, leaves map[string]func() Node) (*BehaviorTree, error) {
+	var def Definition
+	if err := json.Unmarshal(data, &def); err != nil {
+		return nil, err
+	}
+	root, err := def.Build(leaves)
+	if err != nil {
+		return nil, err
+	}
+	return New(root), nil
+}
+
+// LoadJSONWith creates a BehaviorTree from the JSON encoding of the Definition of its root node, using the node
+// types and leaves known to a Registry.
+//
+// Parameters:
+//   - data: The JSON document, as written by BehaviorTree.MarshalJSON.
+//   - r: The Registry used to create the nodes. If nil, only the built-in node types are available.
+//
+// Returns:
+//   - A pointer to a new BehaviorTree instance.
+//   - An error if the document is not valid JSON or the tree cannot be built (see Definition.BuildWith).
+func LoadJSONWith(data []byte, r *Registry) (*BehaviorTree, error) {
+	var def Definition
+	if err := json.Unmarshal(data, &def); err != nil {
+		return nil, err
+	}
+	root, err := def.BuildWith(r)
+	if err != nil {
+		return nil, err
+	}
+	return New(root), nil
+}
+
+// MarshalJSON encodes the BehaviorTree as the JSON encoding of the Definition of its root node. Only the
+// structure and configuration of the tree are encoded, not the status of its nodes or its Blackboard.
+//
+// Returns:
+//   - The JSON document.
+//   - An error if the tree cannot be described (see Describe).
+func (bt *BehaviorTree) MarshalJSON() ([]byte, error) {
+	def, err := Describe(bt.Root)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(def)
+}
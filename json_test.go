@@ -0,0 +1,230 @@
+package behave
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testRegistry returns a Registry with the leaves used by the tree definition tests.
+func testRegistry(t *testing.T) *Registry {
+	t.Helper()
+	r := NewRegistry()
+	for _, err := range []error{
+		r.RegisterAction("Succeed", func() *Action { return &Action{Run: func() Status { return Success }} }),
+		r.RegisterAction("Fail", func() *Action { return &Action{Run: func() Status { return Failure }} }),
+		r.RegisterAction("Unchanged", func() *Action { return &Action{Name: "Custom", Run: func() Status { return Success }} }),
+		r.RegisterCondition("DoorOpen", func() *Condition { return &Condition{Check: func() bool { return true }} }),
+		r.RegisterAsyncAction("Keep", func() *AsyncAction {
+			return &AsyncAction{Run: func(ctx context.Context) Status { <-ctx.Done(); return Failure }}
+		}),
+	} {
+		if err != nil {
+			t.Fatalf("registering test leaves: %v", err)
+		}
+	}
+	return r
+}
+
+func TestLoadJSON(t *testing.T) {
+	data := `{
+		"type": "Sequence",
+		"children": [
+			{"type": "Condition", "name": "DoorOpen"},
+			{"type": "Parallel", "params": {"MinSuccessCount": 2, "Policy": "Threshold"}, "children": [
+				{"type": "Action", "name": "Succeed"},
+				{"type": "RepeatN", "params": {"MaxCount": 3}, "children": [{"type": "Action", "name": "Succeed"}]}
+			]},
+			{"type": "WithTimeout", "params": {"Duration": "250ms"}, "children": [{"type": "Action", "name": "Succeed"}]}
+		]
+	}`
+
+	bt, err := LoadJSONWith([]byte(data), testRegistry(t))
+	if err != nil {
+		t.Fatalf("LoadJSONWith() error = %v", err)
+	}
+	sequence, ok := bt.Root.(*Sequence)
+	if !ok || len(sequence.Children) != 3 {
+		t.Fatalf("LoadJSONWith() root = %v, want a Sequence with 3 children", bt.Root)
+	}
+	if parallel := sequence.Children[1].(*Parallel); parallel.MinSuccessCount != 2 {
+		t.Errorf("Parallel.MinSuccessCount = %d, want 2", parallel.MinSuccessCount)
+	}
+	if repeat := sequence.Children[1].(*Parallel).Children[1].(*RepeatN); repeat.MaxCount != 3 {
+		t.Errorf("RepeatN.MaxCount = %d, want 3", repeat.MaxCount)
+	}
+	if timeout := sequence.Children[2].(*WithTimeout); timeout.Duration != 250*time.Millisecond {
+		t.Errorf("WithTimeout.Duration = %v, want %v", timeout.Duration, 250*time.Millisecond)
+	}
+	if condition := sequence.Children[0].(*Condition); condition.Name != "DoorOpen" {
+		t.Errorf("Condition.Name = %q, want %q", condition.Name, "DoorOpen")
+	}
+	if status := bt.Tick(); status != Running {
+		t.Errorf("BehaviorTree.Tick() = %v, want %v", status, Running)
+	}
+}
+
+func TestBehaviorTree_MarshalJSON_RoundTrip(t *testing.T) {
+	level := slog.LevelWarn
+	bt := New(&Selector{Name: "patrol", Children: []Node{
+		&Composite{
+			Name:       "enter",
+			Conditions: []Node{&Condition{Name: "DoorOpen"}},
+			Child:      &Invert{Child: &Action{Name: "Fail"}},
+		},
+		&ConcurrentParallel{
+			Children:        []Node{&Action{Name: "Succeed"}, &AsyncAction{Name: "Keep"}},
+			Policy:          ParallelAny,
+			MaxConcurrency:  2,
+			MinFailureCount: 1,
+			KeepRunning:     true,
+		},
+		&Retry{
+			Child:       &Action{Name: "Fail"},
+			MaxAttempts: 3,
+			Backoff: JitteredBackoff{
+				Backoff: ExponentialBackoff{Initial: 10 * time.Millisecond, Max: time.Second, Multiplier: 3},
+				Jitter:  0.5,
+			},
+		},
+		&Log{Child: &Forever{Child: &AsyncAction{Name: "Keep"}}, Message: "looping", LogLevel: &level},
+		&Composite{Name: "guard", Conditions: []Node{&Condition{Name: "DoorOpen"}}},
+	}})
+
+	data, err := json.Marshal(bt)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	loaded, err := LoadJSONWith(data, testRegistry(t))
+	if err != nil {
+		t.Fatalf("LoadJSONWith() error = %v\n%s", err, data)
+	}
+	again, err := json.Marshal(loaded)
+	if err != nil {
+		t.Fatalf("json.Marshal() of loaded tree error = %v", err)
+	}
+	if string(again) != string(data) {
+		t.Errorf("round trip changed the tree:\n got %s\nwant %s", again, data)
+	}
+
+	if name := loaded.Root.(*Selector).Children[0].(*Composite).Name; name != "enter" {
+		t.Errorf("Composite.Name = %q, want %q", name, "enter")
+	}
+	retry := loaded.Root.(*Selector).Children[2].(*Retry)
+	jittered, ok := retry.Backoff.(JitteredBackoff)
+	if !ok || jittered.Jitter != 0.5 || jittered.Backoff.(ExponentialBackoff).Multiplier != 3 {
+		t.Errorf("Retry.Backoff = %#v, want the jittered exponential backoff", retry.Backoff)
+	}
+	if log := loaded.Root.(*Selector).Children[3].(*Log); log.LogLevel == nil || *log.LogLevel != slog.LevelWarn {
+		t.Errorf("Log.LogLevel = %v, want %v", log.LogLevel, slog.LevelWarn)
+	}
+	if guard := loaded.Root.(*Selector).Children[4].(*Composite); len(guard.Conditions) != 1 || guard.Child != nil {
+		t.Errorf("Composite without a child = %v, want its condition and no child", guard)
+	}
+}
+
+func TestBehaviorTree_MarshalJSON_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		root Node
+		want error
+	}{
+		{name: "unnamed action", root: &Sequence{Children: []Node{&Action{}}}, want: ErrInvalidConfig},
+		{name: "custom node", root: &testNode{}, want: ErrUnknownNode},
+		{name: "custom backoff", root: &Retry{Child: &Action{Name: "Fail"}, Backoff: testBackoff{}}, want: ErrUnknownNode},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := json.Marshal(New(test.root)); !errors.Is(err, test.want) {
+				t.Errorf("json.Marshal() error = %v, want %v", err, test.want)
+			}
+		})
+	}
+}
+
+type testBackoff struct{}
+
+func (testBackoff) Delay(attempt int) time.Duration { return 0 }
+
+func TestLoadJSON_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want error
+		path string
+	}{
+		{name: "unknown type", data: `{"type": "Teleport"}`, want: ErrUnknownNode, path: "root"},
+		{name: "unknown leaf", data: `{"type": "Sequence", "children": [{"type": "Action", "name": "Fly"}]}`, want: ErrUnknownNode, path: "root/0"},
+		{name: "decorator without child", data: `{"type": "Invert"}`, want: ErrInvalidConfig, path: "root"},
+		{name: "leaf with children", data: `{"type": "Action", "name": "Succeed", "children": [{"type": "Action", "name": "Succeed"}]}`, want: ErrInvalidConfig, path: "root"},
+		{name: "bad duration", data: `{"type": "WithTimeout", "params": {"Duration": 5}, "children": [{"type": "Action", "name": "Succeed"}]}`, want: ErrInvalidConfig, path: "root"},
+		{name: "unknown parameter", data: `{"type": "RepeatN", "params": {"MaxCnt": 5}, "children": [{"type": "Action", "name": "Succeed"}]}`, want: ErrInvalidConfig, path: "root"},
+		{name: "invalid parallel", data: `{"type": "Parallel", "params": {"MinSuccessCount": 3}, "children": [{"type": "Action", "name": "Succeed"}]}`, want: ErrInvalidConfig, path: "root"},
+		{name: "bad policy", data: `{"type": "Parallel", "params": {"Policy": "Most"}}`, want: ErrInvalidConfig, path: "root"},
+		{name: "missing required parameter", data: `{"type": "WithTimeout", "children": [{"type": "Action", "name": "Succeed"}]}`, want: ErrInvalidConfig, path: "root"},
+		{name: "too many children", data: `{"type": "Invert", "children": [{"type": "Action", "name": "Succeed"}, {"type": "Action", "name": "Fail"}]}`, want: ErrInvalidConfig, path: "root"},
+		{name: "wrong leaf kind", data: `{"type": "Sequence", "children": [{"type": "Condition", "name": "Succeed"}]}`, want: ErrInvalidConfig, path: "root/0"},
+		{name: "leaf with parameters", data: `{"type": "Action", "name": "Succeed", "params": {"Speed": 3}}`, want: ErrInvalidConfig, path: "root"},
+		{name: "conditions on sequence", data: `{"type": "Sequence", "conditions": [{"type": "Condition", "name": "DoorOpen"}]}`, want: ErrInvalidConfig, path: "root"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := LoadJSONWith([]byte(test.data), testRegistry(t))
+			if !errors.Is(err, test.want) {
+				t.Fatalf("LoadJSONWith() error = %v, want %v", err, test.want)
+			}
+			if !strings.HasPrefix(err.Error(), test.path+":") {
+				t.Errorf("LoadJSONWith() error = %q, want it to start with the path %q", err, test.path)
+			}
+		})
+	}
+
+	if _, err := LoadJSONWith([]byte(`{"type": `), testRegistry(t)); err == nil {
+		t.Errorf("LoadJSONWith() with malformed JSON should return an error")
+	}
+}
+
+func TestLoadJSON_Leaves(t *testing.T) {
+	data := `{"type": "Selector", "children": [
+		{"type": "Condition", "name": "DoorOpen"},
+		{"type": "Action", "name": "Succeed"},
+		{"type": "Action", "name": "Unchanged"}
+	]}`
+	bt, err := LoadJSON([]byte(data), map[string]func() Node{
+		"DoorOpen":  func() Node { return &Condition{Check: func() bool { return false }} },
+		"Succeed":   func() Node { return &Action{Run: func() Status { return Success }} },
+		"Unchanged": func() Node { return &Action{Name: "Custom"} },
+	})
+	if err != nil {
+		t.Fatalf("LoadJSON() error = %v", err)
+	}
+	if status := bt.Tick(); status != Success {
+		t.Errorf("BehaviorTree.Tick() = %v, want %v", status, Success)
+	}
+	names := []string{NameOf(bt.Root.(*Selector).Children[0]), NameOf(bt.Root.(*Selector).Children[2])}
+	if !reflect.DeepEqual(names, []string{"DoorOpen", "Custom"}) {
+		t.Errorf("leaf names = %q, want the key unless the leaf has a name", names)
+	}
+
+	if _, err := LoadJSON([]byte(data), nil); !errors.Is(err, ErrUnknownNode) {
+		t.Errorf("LoadJSON() without leaves error = %v, want %v", err, ErrUnknownNode)
+	}
+}
+
+func TestDefinition_Build_KeepsLeafName(t *testing.T) {
+	def := &Definition{Type: "Action", Name: "Unchanged"}
+	node, err := def.BuildWith(testRegistry(t))
+	if err != nil {
+		t.Fatalf("Definition.BuildWith() error = %v", err)
+	}
+	if name := node.(*Action).Name; name != "Custom" {
+		t.Errorf("Action.Name = %q, want the name set by the leaf function, %q", name, "Custom")
+	}
+}
@@ -0,0 +1,161 @@
+package behave
+
+import (
+	"context"
+	"strings"
+)
+
+// MemorySelector is a Node that runs its children in order and succeeds if at least one child succeeds.
+// Unlike Selector, it remembers which child is Running: the next tick resumes at that child instead of
+// re-running the earlier children that already failed. It only starts again at the first child once it
+// has completed with Success or Failure, or after it is reset.
+type MemorySelector struct {
+	Name         string // Optional name that identifies the node in String(), logs and tooling
+	Children     []Node
+	status       Status
+	currentIndex int    // Track the index of the child to resume from on the next tick
+	path         string // Path of the node in its tree, assigned by AssignPaths
+}
+
+// Tick executes the MemorySelector node with a background context.
+//
+// Returns:
+//   - The status of the MemorySelector node after execution. See TickContext for details.
+func (ms *MemorySelector) Tick() Status {
+	return ms.TickContext(context.Background())
+}
+
+// TickContext runs the selector, resuming at the child that was Running after the last tick.
+//
+// Parameters:
+//   - ctx: The context for this tick. It is passed to the children of the node.
+//     If ctx is cancelled, the node fails without ticking its children.
+//
+// Returns:
+//   - The status of the MemorySelector node after execution, which can be Ready, Running, Success, or Failure.
+//     The MemorySelector returns Success if a child returns Success, Running if a child is Running, and Failure
+//     if all remaining children have failed.
+func (ms *MemorySelector) TickContext(ctx context.Context) Status {
+	if ctx.Err() != nil {
+		ms.status = Failure
+		return ms.status
+	}
+
+	if ms.currentIndex >= len(ms.Children) {
+		ms.currentIndex = 0
+	}
+
+	// Start from the child that was running after the last tick
+	for i := ms.currentIndex; i < len(ms.Children); i++ {
+		status := TickNode(ctx, ms.Children[i])
+		switch status {
+		case Failure:
+			// This child failed, move to the next child
+			continue
+		case Ready, Running:
+			// Resume at this child on the next tick
+			ms.currentIndex = i
+			ms.status = status
+			return ms.status
+		case Success:
+			// The selector has completed, start over on the next tick
+			ms.currentIndex = 0
+			ms.status = status
+			return ms.status
+		default:
+			ms.currentIndex = 0
+			ms.status = Failure
+			return ms.status
+		}
+	}
+	ms.currentIndex = 0
+	ms.status = Failure
+	return ms.status
+}
+
+// Reset resets the MemorySelector node and all its children to their initial state.
+//
+// Returns:
+//   - The status of the MemorySelector node after reset, which will be Ready. This method resets all
+//     child nodes to their initial state and starts the selector again at the first child.
+func (ms *MemorySelector) Reset() Status {
+	for _, child := range ms.Children {
+		child.Reset()
+	}
+	ms.currentIndex = 0
+	ms.status = Ready
+	return ms.status
+}
+
+// Halt interrupts the MemorySelector node and all its children.
+//
+// Returns:
+//   - The status of the MemorySelector node after halting, which will be Ready. Running child nodes are halted,
+//     while all others are reset, and the selector starts again at the first child.
+func (ms *MemorySelector) Halt() Status {
+	for _, child := range ms.Children {
+		HaltNode(child)
+	}
+	ms.currentIndex = 0
+	ms.status = Ready
+	return ms.status
+}
+
+// Status returns the current status of the MemorySelector node.
+//
+// Returns:
+//   - The current status of the MemorySelector node, which can be Ready, Running, Success, or Failure.
+func (ms *MemorySelector) Status() Status {
+	return ms.status
+}
+
+// String returns a string representation of the MemorySelector node.
+//
+// Returns:
+//   - A string that represents the MemorySelector node, including its current status and all child nodes.
+//     The format is: MemorySelector (Status), with the name and path of the node after its type when they are set.
+func (ms *MemorySelector) String() string {
+	var builder strings.Builder
+	builder.WriteString(nodeLabel("MemorySelector", ms.Name, ms.path) + " (" + ms.Status().String() + ")")
+	for _, child := range ms.Children {
+		str := child.String()
+		lines := strings.Split(str, "\n")
+		for _, line := range lines {
+			builder.WriteString("\n  ")
+			builder.WriteString(line)
+		}
+	}
+	return builder.String()
+}
+
+// NodeName returns the optional name of the MemorySelector node.
+//
+// Returns:
+//   - The Name of the node, or the empty string if it has none.
+func (ms *MemorySelector) NodeName() string {
+	return ms.Name
+}
+
+// Path returns the path of the MemorySelector node in its tree, such as "root/0/2".
+//
+// Returns:
+//   - The path assigned by AssignPaths, or the empty string if none has been assigned.
+func (ms *MemorySelector) Path() string {
+	return ms.path
+}
+
+// SetPath sets the path of the MemorySelector node in its tree. It is called by AssignPaths.
+//
+// Parameters:
+//   - path: The path of the node.
+func (ms *MemorySelector) SetPath(path string) {
+	ms.path = path
+}
+
+// ChildNodes returns the children of the MemorySelector node.
+//
+// Returns:
+//   - The Children of the node, in order.
+func (ms *MemorySelector) ChildNodes() []Node {
+	return ms.Children
+}
@@ -0,0 +1,137 @@
+package behave
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMemorySelector_Tick(t *testing.T) {
+	tests := []struct {
+		name     string
+		children []Node
+		expected Status
+	}{
+		{
+			name:     "empty selector",
+			children: []Node{},
+			expected: Failure,
+		},
+		{
+			name: "second succeeds",
+			children: []Node{
+				&Action{Run: func() Status { return Failure }},
+				&Action{Run: func() Status { return Success }},
+			},
+			expected: Success,
+		},
+		{
+			name: "all fail",
+			children: []Node{
+				&Action{Run: func() Status { return Failure }},
+				&Action{Run: func() Status { return Failure }},
+			},
+			expected: Failure,
+		},
+		{
+			name: "second running",
+			children: []Node{
+				&Action{Run: func() Status { return Failure }},
+				&Action{Run: func() Status { return Running }},
+			},
+			expected: Running,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			selector := &MemorySelector{Children: test.children}
+			status := selector.Tick()
+			if status != test.expected {
+				t.Errorf("MemorySelector.Tick() = %v, want %v", status, test.expected)
+			}
+			if selector.Status() != test.expected {
+				t.Errorf("MemorySelector.Status() after Tick() = %v, want %v", selector.Status(), test.expected)
+			}
+		})
+	}
+}
+
+func TestMemorySelector_ResumesAtRunningChild(t *testing.T) {
+	firstRuns := 0
+	secondTicks := 0
+	selector := &MemorySelector{Children: []Node{
+		&Action{Run: func() Status {
+			firstRuns++
+			return Failure
+		}},
+		&Action{Run: func() Status {
+			secondTicks++
+			if secondTicks < 3 {
+				return Running
+			}
+			return Success
+		}},
+	}}
+
+	expected := []Status{Running, Running, Success}
+	for i, want := range expected {
+		if status := selector.Tick(); status != want {
+			t.Errorf("MemorySelector.Tick() call %d = %v, want %v", i+1, status, want)
+		}
+	}
+	if firstRuns != 1 {
+		t.Errorf("First fallback ran %d times, want 1 (the failed child must not be re-run)", firstRuns)
+	}
+
+	// After completing, the selector starts again at the first child
+	selector.Tick()
+	if firstRuns != 2 {
+		t.Errorf("First fallback ran %d times after completion, want 2", firstRuns)
+	}
+}
+
+func TestMemorySelector_RestartsAfterFailure(t *testing.T) {
+	runs := []int{0, 0}
+	selector := &MemorySelector{Children: []Node{
+		&Action{Run: func() Status { runs[0]++; return Failure }},
+		&Action{Run: func() Status { runs[1]++; return Failure }},
+	}}
+
+	selector.Tick()
+	selector.Tick()
+	if runs[0] != 2 || runs[1] != 2 {
+		t.Errorf("Children ran %v times, want [2 2]", runs)
+	}
+}
+
+func TestMemorySelector_ResetAndHalt(t *testing.T) {
+	firstRuns := 0
+	halted := 0
+	selector := &MemorySelector{Children: []Node{
+		&Action{Run: func() Status { firstRuns++; return Failure }},
+		&Action{Run: func() Status { return Running }, OnHalt: func() { halted++ }},
+	}}
+
+	selector.Tick()
+	if status := selector.Reset(); status != Ready {
+		t.Errorf("MemorySelector.Reset() = %v, want %v", status, Ready)
+	}
+	selector.Tick()
+	if firstRuns != 2 {
+		t.Errorf("First child ran %d times after Reset(), want 2", firstRuns)
+	}
+
+	if status := selector.Halt(); status != Ready {
+		t.Errorf("MemorySelector.Halt() = %v, want %v", status, Ready)
+	}
+	if halted != 1 {
+		t.Errorf("OnHalt called %d times, want 1", halted)
+	}
+
+	str := selector.String()
+	for _, expected := range []string{"MemorySelector (Ready)", "Action"} {
+		if !strings.Contains(str, expected) {
+			t.Errorf("MemorySelector.String() should contain %q, got %v", expected, str)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package behave
+
+import (
+	"strings"
+)
+
+// Mermaid returns a Mermaid flowchart of the node and all the nodes below it, which wikis and Markdown
+// renderers that support Mermaid draw natively. Each node is labeled with its type, name and parameters, and
+// with its current Status if opts.Status is set. Conditions are drawn as stadiums and all other nodes as
+// rounded boxes. The edges from a Composite node are labeled "condition" or "child".
+//
+// Parameters:
+//   - node: The root of the subtree to draw.
+//   - opts: What to include in the diagram.
+//
+// Returns:
+//   - The Mermaid source of the diagram.
+func Mermaid(node Node, opts DiagramOptions) string {
+	var builder strings.Builder
+	builder.WriteString("flowchart TD\n")
+	if node == nil {
+		return builder.String()
+	}
+
+	root := newDiagram(node)
+	nodes := root.nodes()
+	for _, d := range nodes {
+		label := mermaidQuote(strings.Join(d.lines(opts.Status), "<br/>"))
+		if d.check {
+			builder.WriteString("  " + d.id + "([" + label + "])\n")
+		} else {
+			builder.WriteString("  " + d.id + "(" + label + ")\n")
+		}
+	}
+	root.walk(func(parent *diagramNode, edge diagramEdge) {
+		builder.WriteString("  " + parent.id + " -->")
+		if edge.label != "" {
+			builder.WriteString("|" + edge.label + "|")
+		}
+		builder.WriteString(" " + edge.node.id + "\n")
+	})
+
+	if opts.Status {
+		// Color the nodes by Status, declaring a class only for the statuses in use
+		for _, status := range []Status{Ready, Running, Success, Failure} {
+			var ids []string
+			for _, d := range nodes {
+				if d.status == status {
+					ids = append(ids, d.id)
+				}
+			}
+			if len(ids) == 0 {
+				continue
+			}
+			class := strings.ToLower(status.String())
+			builder.WriteString("  classDef " + class + " fill:" + statusColors[status] + "\n")
+			builder.WriteString("  class " + strings.Join(ids, ",") + " " + class + "\n")
+		}
+	}
+	return builder.String()
+}
+
+// Mermaid returns a Mermaid flowchart of the BehaviorTree. See Mermaid for details.
+//
+// Parameters:
+//   - opts: What to include in the diagram.
+//
+// Returns:
+//   - The Mermaid source of the diagram of the tree's Root.
+func (bt *BehaviorTree) Mermaid(opts DiagramOptions) string {
+	return Mermaid(bt.Root, opts)
+}
+
+// mermaidQuote returns s as a quoted Mermaid label, writing double quotes as the #quot; entity code.
+func mermaidQuote(s string) string {
+	s = strings.ReplaceAll(s, `"`, "#quot;")
+	return `"` + s + `"`
+}
@@ -0,0 +1,308 @@
+package behave
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultMetricsBuckets are the upper bounds, in seconds, of the tick latency histograms of a Metrics when
+// MetricsOptions.Buckets is not set. They range from 100µs for fast leaves to 10s for long-running subtrees.
+var DefaultMetricsBuckets = []float64{.0001, .0005, .001, .005, .01, .05, .1, .5, 1, 5, 10}
+
+// MetricsOptions configures a Metrics. Every histogram ends with a +Inf bucket, so bounds in Buckets that are NaN,
+// +Inf or repeated are ignored.
+type MetricsOptions struct {
+	Buckets []float64 // Upper bounds of the tick latency histograms, in seconds. If nil, DefaultMetricsBuckets are used
+}
+
+// Metrics collects per-node and per-tree tick metrics, and renders them in the Prometheus text exposition format.
+// For every node, it counts the ticks by resulting Status and keeps a histogram of the tick latency; for every
+// tree, it does the same for whole ticks. The tick rate of a tree is the rate of behave_tree_ticks_total, and the
+// tick counts are also the _count series of the histograms. The metrics are:
+//
+//   - behave_tree_ticks_total{tree, status}: Ticks of each tree, by resulting status.
+//   - behave_tree_tick_duration_seconds{tree}: Histogram of the time each tick of the tree took.
+//   - behave_node_ticks_total{tree, path, name, type, status}: Ticks of each node, by resulting status.
+//   - behave_node_tick_duration_seconds{tree, path, name, type}: Histogram of the time each tick of the node took.
+//
+// Metrics hooks into the trees it is attached to as an Observer, so no metric logic is needed in the nodes, and
+// latencies are measured with each tree's Clock. Metrics is an http.Handler that serves the metrics to a
+// Prometheus server. A Metrics is safe for concurrent use.
+type Metrics struct {
+	mu       sync.Mutex
+	buckets  []float64
+	trees    map[string]*tickMetrics
+	nodes    map[nodeMetricsKey]*tickMetrics
+	attached map[*BehaviorTree]*metricsAttachment // Current attachment of each tree, so that a tree is never counted twice
+}
+
+// metricsAttachment is the registration of the Observer through which a Metrics collects the metrics of a tree.
+type metricsAttachment struct {
+	remove func()
+}
+
+// nodeMetricsKey identifies the node metrics are collected for.
+type nodeMetricsKey struct {
+	tree, path, name, kind string
+}
+
+// tickMetrics holds the metrics of a node or tree.
+type tickMetrics struct {
+	statuses map[Status]uint64 // Number of ticks by resulting status
+	counts   []uint64          // Number of ticks in each latency bucket, not cumulative. The last bucket is +Inf
+	sum      float64           // Total latency of the ticks, in seconds
+}
+
+// NewMetrics creates a new Metrics.
+//
+// Parameters:
+//   - options: The buckets of the latency histograms.
+//
+// Returns:
+//   - A pointer to a new Metrics instance with no metrics.
+func NewMetrics(options MetricsOptions) *Metrics {
+	buckets := options.Buckets
+	if buckets == nil {
+		buckets = DefaultMetricsBuckets
+	}
+	bounds := make([]float64, 0, len(buckets))
+	for _, bound := range buckets {
+		if !math.IsNaN(bound) && !math.IsInf(bound, 1) {
+			bounds = append(bounds, bound)
+		}
+	}
+	sort.Float64s(bounds)
+	bounds = slices.Compact(bounds)
+	return &Metrics{
+		buckets:  bounds,
+		trees:    map[string]*tickMetrics{},
+		nodes:    map[nodeMetricsKey]*tickMetrics{},
+		attached: map[*BehaviorTree]*metricsAttachment{},
+	}
+}
+
+// Attach starts collecting the metrics of a BehaviorTree. Attaching a tree that is already attached replaces the
+// earlier attachment, so that its ticks are never counted twice.
+//
+// Parameters:
+//   - bt: The tree to collect metrics for.
+//   - tree: The name the metrics of the tree are labeled with. Metrics of trees attached with the same name are
+//     combined.
+//
+// Returns:
+//   - A function that stops collecting the metrics of the tree. The metrics collected so far are kept.
+func (m *Metrics) Attach(bt *BehaviorTree, tree string) func() {
+	current := &metricsAttachment{remove: bt.AddObserver(metricsObserver{metrics: m, tree: tree})}
+	m.mu.Lock()
+	previous := m.attached[bt]
+	m.attached[bt] = current
+	m.mu.Unlock()
+	if previous != nil {
+		previous.remove()
+	}
+	return func() {
+		m.mu.Lock()
+		if m.attached[bt] == current {
+			delete(m.attached, bt)
+		}
+		m.mu.Unlock()
+		current.remove()
+	}
+}
+
+// metricsObserver collects the metrics of a tree attached to a Metrics under the given name.
+type metricsObserver struct {
+	metrics *Metrics
+	tree    string
+}
+
+// OnEnter ignores the event, since metrics are collected once the tick of a node is over.
+func (o metricsObserver) OnEnter(event TickEvent) {}
+
+// OnExit records the tick of a node of the tree.
+func (o metricsObserver) OnExit(event TickEvent) {
+	o.metrics.mu.Lock()
+	defer o.metrics.mu.Unlock()
+	key := nodeMetricsKey{tree: o.tree, path: event.Path, name: event.Name, kind: typeName(event.Node)}
+	o.metrics.node(key).add(event.Status, event.Duration, o.metrics.buckets)
+}
+
+// OnTick records a tick of the tree, including one that failed without ticking the root.
+func (o metricsObserver) OnTick(event TickEvent) {
+	o.metrics.mu.Lock()
+	defer o.metrics.mu.Unlock()
+	o.metrics.tree(o.tree).add(event.Status, event.Duration, o.metrics.buckets)
+}
+
+// tree returns the metrics of the named tree, creating them if needed.
+func (m *Metrics) tree(tree string) *tickMetrics {
+	if m.trees[tree] == nil {
+		m.trees[tree] = newTickMetrics(m.buckets)
+	}
+	return m.trees[tree]
+}
+
+// node returns the metrics of a node, creating them if needed.
+func (m *Metrics) node(key nodeMetricsKey) *tickMetrics {
+	if m.nodes[key] == nil {
+		m.nodes[key] = newTickMetrics(m.buckets)
+	}
+	return m.nodes[key]
+}
+
+// newTickMetrics creates empty metrics with the given latency buckets.
+func newTickMetrics(buckets []float64) *tickMetrics {
+	return &tickMetrics{statuses: map[Status]uint64{}, counts: make([]uint64, len(buckets)+1)}
+}
+
+// add records a tick.
+func (t *tickMetrics) add(status Status, duration time.Duration, buckets []float64) {
+	seconds := duration.Seconds()
+	t.statuses[status]++
+	t.counts[sort.SearchFloat64s(buckets, seconds)]++
+	t.sum += seconds
+}
+
+// WriteText writes the metrics in the Prometheus text exposition format, version 0.0.4.
+//
+// Parameters:
+//   - w: The writer to write the metrics to.
+//
+// Returns:
+//   - An error if the metrics cannot be written.
+func (m *Metrics) WriteText(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	trees := make([]string, 0, len(m.trees))
+	for tree := range m.trees {
+		trees = append(trees, tree)
+	}
+	sort.Strings(trees)
+	nodes := make([]nodeMetricsKey, 0, len(m.nodes))
+	for key := range m.nodes {
+		nodes = append(nodes, key)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		a, b := nodes[i], nodes[j]
+		if a.tree != b.tree {
+			return a.tree < b.tree
+		}
+		if a.path != b.path {
+			return a.path < b.path
+		}
+		if a.name != b.name {
+			return a.name < b.name
+		}
+		return a.kind < b.kind
+	})
+
+	out := bufio.NewWriter(w)
+	promHeader(out, "behave_tree_ticks_total", "counter", "Number of ticks of each tree, by resulting status.")
+	for _, tree := range trees {
+		m.trees[tree].writeStatuses(out, "behave_tree_ticks_total", promLabels("tree", tree))
+	}
+	promHeader(out, "behave_tree_tick_duration_seconds", "histogram", "Time each tick of the tree took, in seconds.")
+	for _, tree := range trees {
+		m.trees[tree].writeHistogram(out, "behave_tree_tick_duration_seconds", promLabels("tree", tree), m.buckets)
+	}
+	promHeader(out, "behave_node_ticks_total", "counter", "Number of ticks of each node, by resulting status.")
+	for _, key := range nodes {
+		m.nodes[key].writeStatuses(out, "behave_node_ticks_total", key.labels())
+	}
+	promHeader(out, "behave_node_tick_duration_seconds", "histogram", "Time each tick of the node took, in seconds.")
+	for _, key := range nodes {
+		m.nodes[key].writeHistogram(out, "behave_node_tick_duration_seconds", key.labels(), m.buckets)
+	}
+	return out.Flush()
+}
+
+// ServeHTTP serves the metrics in the Prometheus text exposition format. The metrics are rendered before the
+// response is written, so that an error rendering them is answered with an internal server error.
+//
+// Parameters:
+//   - w: The writer to write the response to.
+//   - r: The request, which is not used.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body bytes.Buffer
+	if err := m.WriteText(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	// An error writing the response means that the client has gone away, so there is no one left to report it to
+	_, _ = body.WriteTo(w)
+}
+
+// labels returns the Prometheus labels of the node metrics are collected for.
+func (k nodeMetricsKey) labels() string {
+	return promLabels("tree", k.tree, "path", k.path, "name", k.name, "type", k.kind)
+}
+
+// writeStatuses writes a counter series for every status the metrics have recorded a tick for.
+func (t *tickMetrics) writeStatuses(w io.Writer, metric, labelSet string) {
+	statuses := make([]Status, 0, len(t.statuses))
+	for status := range t.statuses {
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i] < statuses[j] })
+	for _, status := range statuses {
+		fmt.Fprintf(w, "%s{%s,%s} %d\n", metric, labelSet, promLabels("status", status.String()), t.statuses[status])
+	}
+}
+
+// writeHistogram writes the latency histogram of the metrics.
+func (t *tickMetrics) writeHistogram(w io.Writer, metric, labelSet string, buckets []float64) {
+	var cumulative uint64
+	for i, count := range t.counts {
+		cumulative += count
+		bound := math.Inf(1)
+		if i < len(buckets) {
+			bound = buckets[i]
+		}
+		fmt.Fprintf(w, "%s_bucket{%s,le=%q} %d\n", metric, labelSet, promFloat(bound), cumulative)
+	}
+	fmt.Fprintf(w, "%s_sum{%s} %s\n", metric, labelSet, promFloat(t.sum))
+	fmt.Fprintf(w, "%s_count{%s} %d\n", metric, labelSet, cumulative)
+}
+
+// promHeader writes the HELP and TYPE lines of a metric.
+func promHeader(w io.Writer, metric, kind, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", metric, help, metric, kind)
+}
+
+// promLabels formats pairs of label names and values as a Prometheus label set, without the enclosing braces.
+func promLabels(pairs ...string) string {
+	var sb strings.Builder
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		sb.WriteString(pairs[i])
+		sb.WriteString(`="`)
+		sb.WriteString(labelEscaper.Replace(pairs[i+1]))
+		sb.WriteString(`"`)
+	}
+	return sb.String()
+}
+
+// labelEscaper escapes label values as required by the Prometheus text exposition format.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// promFloat formats a float as a Prometheus sample value.
+func promFloat(f float64) string {
+	if math.IsInf(f, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
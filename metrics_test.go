@@ -0,0 +1,138 @@
+package behave
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetrics(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	ticks := 0
+	bt := New(&Sequence{Name: "patrol", Children: []Node{
+		&Action{Name: "walk", Run: func() Status {
+			clock.Advance(2 * time.Millisecond)
+			ticks++
+			if ticks == 1 {
+				return Running
+			}
+			return Success
+		}},
+	}})
+	bt.Clock = clock
+	metrics := NewMetrics(MetricsOptions{Buckets: []float64{.01, .001}})
+	detach := metrics.Attach(bt, "guard")
+	bt.Tick()
+	bt.Tick()
+	detach()
+	bt.Tick()
+
+	var out bytes.Buffer
+	if err := metrics.WriteText(&out); err != nil {
+		t.Fatalf("Metrics.WriteText() error = %v", err)
+	}
+	text := out.String()
+	node := `tree="guard",path="root/0",name="walk",type="Action"`
+	for _, line := range []string{
+		"# TYPE behave_tree_ticks_total counter",
+		`behave_tree_ticks_total{tree="guard",status="Running"} 1`,
+		`behave_tree_ticks_total{tree="guard",status="Success"} 1`,
+		"# TYPE behave_tree_tick_duration_seconds histogram",
+		`behave_tree_tick_duration_seconds_bucket{tree="guard",le="0.001"} 0`,
+		`behave_tree_tick_duration_seconds_bucket{tree="guard",le="0.01"} 2`,
+		`behave_tree_tick_duration_seconds_bucket{tree="guard",le="+Inf"} 2`,
+		`behave_tree_tick_duration_seconds_sum{tree="guard"} 0.004`,
+		`behave_tree_tick_duration_seconds_count{tree="guard"} 2`,
+		`behave_node_ticks_total{tree="guard",path="root",name="patrol",type="Sequence",status="Success"} 1`,
+		`behave_node_ticks_total{` + node + `,status="Running"} 1`,
+		`behave_node_ticks_total{` + node + `,status="Success"} 1`,
+		`behave_node_tick_duration_seconds_count{` + node + `} 2`,
+	} {
+		if !strings.Contains(text, line+"\n") {
+			t.Errorf("Metrics.WriteText() is missing %s in\n%s", line, text)
+		}
+	}
+	if strings.Contains(text, `status="Failure"`) || strings.Count(text, "# TYPE") != 4 {
+		t.Errorf("Metrics.WriteText() = \n%s\nwant 4 metrics with series only for the statuses seen", text)
+	}
+}
+
+func TestMetrics_BucketsAndAttach(t *testing.T) {
+	metrics := NewMetrics(MetricsOptions{Buckets: []float64{math.Inf(1), .01, math.NaN(), .01}})
+	bt := New(&Action{Run: func() Status { return Success }})
+	detach := metrics.Attach(bt, "guard")
+	metrics.Attach(bt, "guard")
+	bt.Tick()
+	// The first attachment has already been replaced, so detaching it leaves the second one in place
+	detach()
+	bt.Tick()
+
+	var out bytes.Buffer
+	if err := metrics.WriteText(&out); err != nil {
+		t.Fatalf("Metrics.WriteText() error = %v", err)
+	}
+	text := out.String()
+	for _, line := range []string{
+		`behave_tree_tick_duration_seconds_bucket{tree="guard",le="0.01"} 2`,
+		`behave_tree_tick_duration_seconds_bucket{tree="guard",le="+Inf"} 2`,
+		`behave_tree_ticks_total{tree="guard",status="Success"} 2`,
+	} {
+		if strings.Count(text, line+"\n") != 1 {
+			t.Errorf("Metrics.WriteText() should contain %s once in\n%s", line, text)
+		}
+	}
+	if strings.Contains(text, "NaN") || strings.Count(text, `le="`) != 4 {
+		t.Errorf("Metrics.WriteText() = \n%s\nwant the buckets 0.01 and +Inf only", text)
+	}
+}
+
+func TestMetrics_TreeTicks(t *testing.T) {
+	metrics := NewMetrics(MetricsOptions{})
+	// The root doesn't implement Identifiable, so it has no path
+	bt := New(&testNode{
+		tickFunc:   func() Status { return Success },
+		statusFunc: func() Status { return Ready },
+	})
+	metrics.Attach(bt, "guard")
+	bt.Tick()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	bt.TickContext(ctx)
+	bt.Root = nil
+	bt.Tick()
+
+	var out bytes.Buffer
+	if err := metrics.WriteText(&out); err != nil {
+		t.Fatalf("Metrics.WriteText() error = %v", err)
+	}
+	text := out.String()
+	for _, line := range []string{
+		`behave_tree_ticks_total{tree="guard",status="Success"} 1`,
+		`behave_tree_ticks_total{tree="guard",status="Failure"} 2`,
+		`behave_tree_tick_duration_seconds_count{tree="guard"} 3`,
+	} {
+		if !strings.Contains(text, line+"\n") {
+			t.Errorf("Metrics.WriteText() is missing %s in\n%s", line, text)
+		}
+	}
+}
+
+func TestMetrics_ServeHTTP(t *testing.T) {
+	metrics := NewMetrics(MetricsOptions{})
+	bt := New(&Condition{Check: func() bool { return false }})
+	metrics.Attach(bt, `door "A"`)
+	bt.Tick()
+
+	response := httptest.NewRecorder()
+	metrics.ServeHTTP(response, httptest.NewRequest("GET", "/metrics", nil))
+	if contentType := response.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type = %q, want the Prometheus text format", contentType)
+	}
+	if body := response.Body.String(); !strings.Contains(body, `behave_tree_ticks_total{tree="door \"A\"",status="Failure"} 1`) {
+		t.Errorf("Metrics.ServeHTTP() = \n%s\nwant the tree name escaped", body)
+	}
+}
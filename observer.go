@@ -0,0 +1,124 @@
+package behave
+
+import (
+	"context"
+	"time"
+)
+
+// TickEvent describes the tick of a single node, as reported to an Observer.
+type TickEvent struct {
+	Node     Node          // The node being ticked
+	Name     string        // Name of the node, or the empty string if it has none (see NameOf)
+	Path     string        // Path of the node in its tree, such as "root/0/2" (see PathOf)
+	Previous Status        // Status of the node before the tick
+	Status   Status        // Status of the node after the tick. In an enter event, it is the same as Previous
+	Start    time.Time     // Time at which the tick started, read from the tick's Clock
+	Duration time.Duration // Time the tick took, including the ticks of the nodes below it. Zero in an enter event
+}
+
+// Observer receives an event when a node starts and finishes a tick. Observers see every node ticked with
+// TickNode, which all built-in composite and decorator nodes use to tick their children, so the nodes of a tree
+// don't need to be wrapped to be observed. Events are delivered on the goroutine that ticks the node; since
+// ConcurrentParallel ticks its children on separate goroutines, an Observer must be safe for concurrent use.
+type Observer interface {
+	OnEnter(event TickEvent) // Called before the node is ticked
+	OnExit(event TickEvent)  // Called after the node is ticked
+}
+
+// TreeObserver is implemented by observers that also need to know when a whole tick of a tree is over. An
+// Observer added with BehaviorTree.AddObserver that implements TreeObserver is notified once at the end of every
+// tick of the tree, including the ticks that fail without ticking the root because it is nil or the context of
+// the tick is cancelled.
+type TreeObserver interface {
+	OnTick(event TickEvent) // Called after each tick of the tree. Node is the tree's Root, which may be nil, and Path is "root"
+}
+
+// ObserverFuncs is an Observer that calls the functions it holds. Either function can be nil.
+type ObserverFuncs struct {
+	Enter func(event TickEvent) // Called before a node is ticked
+	Exit  func(event TickEvent) // Called after a node is ticked
+}
+
+// OnEnter calls Enter, if it is set.
+//
+// Parameters:
+//   - event: The event describing the node about to be ticked.
+func (o ObserverFuncs) OnEnter(event TickEvent) {
+	if o.Enter != nil {
+		o.Enter(event)
+	}
+}
+
+// OnExit calls Exit, if it is set.
+//
+// Parameters:
+//   - event: The event describing the node that was ticked.
+func (o ObserverFuncs) OnExit(event TickEvent) {
+	if o.Exit != nil {
+		o.Exit(event)
+	}
+}
+
+// observers is an Observer that forwards every event to a list of observers, in order.
+type observers []Observer
+
+// OnEnter forwards the event to every observer.
+func (o observers) OnEnter(event TickEvent) {
+	for _, observer := range o {
+		observer.OnEnter(event)
+	}
+}
+
+// OnExit forwards the event to every observer.
+func (o observers) OnExit(event TickEvent) {
+	for _, observer := range o {
+		observer.OnExit(event)
+	}
+}
+
+// observerKey is the context key under which the observers of a tick are stored.
+type observerKey struct{}
+
+// WithObserver returns a copy of ctx that carries the given Observer, in addition to any Observer already carried
+// by ctx. BehaviorTree.TickContext uses it to pass the observers added with AddObserver to every node in the tree.
+//
+// Parameters:
+//   - ctx: The parent context.
+//   - observer: The Observer to add to the context.
+//
+// Returns:
+//   - A new context carrying the Observer.
+func WithObserver(ctx context.Context, observer Observer) context.Context {
+	existing, _ := ctx.Value(observerKey{}).(observers)
+	combined := make(observers, 0, len(existing)+1)
+	combined = append(combined, existing...)
+	return context.WithValue(ctx, observerKey{}, append(combined, observer))
+}
+
+// observeTick ticks the node, reporting the tick to the observers carried by ctx.
+func observeTick(ctx context.Context, node Node, tick func() Status) Status {
+	o, _ := ctx.Value(observerKey{}).(observers)
+	if len(o) == 0 {
+		return tick()
+	}
+
+	clock := ClockFromContext(ctx)
+	event := TickEvent{Node: node, Name: NameOf(node), Path: PathOf(node), Start: clock.Now()}
+	event.Previous = idleStatus(node)
+	event.Status = event.Previous
+	o.OnEnter(event)
+
+	event.Status = tick()
+	event.Duration = clock.Now().Sub(event.Start)
+	o.OnExit(event)
+	return event.Status
+}
+
+// idleStatus returns the status of a node between ticks, without side effects. A Condition keeps no status and
+// evaluates its check when asked for one, so it is Ready between ticks.
+func idleStatus(node Node) Status {
+	if _, ok := node.(*Condition); ok {
+		return Ready
+	}
+	return node.Status()
+}
@@ -0,0 +1,123 @@
+package behave
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// eventLog is an Observer that records the events it receives as strings.
+type eventLog struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (l *eventLog) OnEnter(event TickEvent) {
+	l.add(fmt.Sprintf("enter %s %s", event.Path, event.Previous))
+}
+
+func (l *eventLog) OnExit(event TickEvent) {
+	l.add(fmt.Sprintf("exit %s %s->%s", event.Path, event.Previous, event.Status))
+}
+
+func (l *eventLog) add(event string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, event)
+}
+
+func TestBehaviorTree_AddObserver(t *testing.T) {
+	ticks := 0
+	bt := New(&Sequence{Children: []Node{
+		&Condition{Check: func() bool { return true }},
+		&Invert{Child: &Action{Run: func() Status {
+			ticks++
+			if ticks == 1 {
+				return Running
+			}
+			return Failure
+		}}},
+	}})
+	log := &eventLog{}
+	remove := bt.AddObserver(log)
+
+	bt.Tick()
+	bt.Tick()
+	want := []string{
+		"enter root Ready", "enter root/0 Ready", "exit root/0 Ready->Success",
+		"enter root/1 Ready", "enter root/1/0 Ready", "exit root/1/0 Ready->Running", "exit root/1 Ready->Running",
+		"exit root Ready->Running",
+		"enter root Running", "enter root/1 Running", "enter root/1/0 Running", "exit root/1/0 Running->Failure",
+		"exit root/1 Running->Success", "exit root Running->Success",
+	}
+	if !reflect.DeepEqual(log.events, want) {
+		t.Errorf("observed events\n%q\nwant\n%q", log.events, want)
+	}
+
+	remove()
+	remove()
+	bt.Reset()
+	bt.Tick()
+	if len(log.events) != len(want) {
+		t.Errorf("a removed Observer received %d more events", len(log.events)-len(want))
+	}
+}
+
+func TestBehaviorTree_ObserverDuration(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	bt := New(&Sequence{Children: []Node{
+		&Action{Name: "slow", Run: func() Status {
+			clock.Advance(3 * time.Second)
+			return Success
+		}},
+	}})
+	bt.Clock = clock
+
+	durations := map[string]time.Duration{}
+	var names []string
+	bt.AddObserver(ObserverFuncs{Exit: func(event TickEvent) {
+		durations[event.Path] = event.Duration
+		names = append(names, event.Name)
+	}})
+	bt.Tick()
+
+	if durations["root/0"] != 3*time.Second || durations["root"] != 3*time.Second {
+		t.Errorf("observed durations = %v, want 3s for the action and the sequence", durations)
+	}
+	if !reflect.DeepEqual(names, []string{"slow", ""}) {
+		t.Errorf("observed names = %q, want the action's name and no name for the sequence", names)
+	}
+}
+
+func TestBehaviorTree_ObserverConcurrent(t *testing.T) {
+	children := make([]Node, 8)
+	for i := range children {
+		children[i] = &Action{Run: func() Status { return Success }}
+	}
+	bt := New(&ConcurrentParallel{Policy: ParallelAll, Children: children})
+	log := &eventLog{}
+	bt.AddObserver(log)
+
+	if status := bt.Tick(); status != Success {
+		t.Fatalf("BehaviorTree.Tick() = %v, want %v", status, Success)
+	}
+	if len(log.events) != 2*(len(children)+1) {
+		t.Errorf("observed %d events, want %d", len(log.events), 2*(len(children)+1))
+	}
+}
+
+func TestWithObserver(t *testing.T) {
+	first, second := &eventLog{}, &eventLog{}
+	ctx := WithObserver(WithObserver(context.Background(), first), second)
+
+	TickNode(ctx, &Action{Run: func() Status { return Success }})
+	TickNode(context.Background(), &Action{Run: func() Status { return Success }})
+
+	want := []string{"enter  Ready", "exit  Ready->Success"}
+	if !reflect.DeepEqual(first.events, want) || !reflect.DeepEqual(second.events, want) {
+		t.Errorf("observed events = %q and %q, want %q for both observers", first.events, second.events, want)
+	}
+}
@@ -0,0 +1,64 @@
+package behave
+
+import (
+	"strings"
+)
+
+// PlantUML returns a PlantUML diagram of the node and all the nodes below it, which wikis that support
+// PlantUML draw natively. Each node is labeled with its type, name and parameters, and with its current
+// Status if opts.Status is set, in which case it is also filled with a color for its Status. Conditions
+// are drawn as ellipses and all other nodes as rectangles. The edges from a Composite node are labeled
+// "condition" or "child".
+//
+// Parameters:
+//   - node: The root of the subtree to draw.
+//   - opts: What to include in the diagram.
+//
+// Returns:
+//   - The PlantUML source of the diagram, from @startuml to @enduml.
+func PlantUML(node Node, opts DiagramOptions) string {
+	var builder strings.Builder
+	builder.WriteString("@startuml\n")
+	if node != nil {
+		root := newDiagram(node)
+		for _, d := range root.nodes() {
+			shape := "rectangle"
+			if d.check {
+				shape = "usecase"
+			}
+			builder.WriteString(shape + " " + plantUMLQuote(strings.Join(d.lines(opts.Status), "\n")) + " as " + d.id)
+			if opts.Status {
+				builder.WriteString(" " + statusColors[d.status])
+			}
+			builder.WriteString("\n")
+		}
+		root.walk(func(parent *diagramNode, edge diagramEdge) {
+			builder.WriteString(parent.id + " --> " + edge.node.id)
+			if edge.label != "" {
+				builder.WriteString(" : " + edge.label)
+			}
+			builder.WriteString("\n")
+		})
+	}
+	builder.WriteString("@enduml\n")
+	return builder.String()
+}
+
+// PlantUML returns a PlantUML diagram of the BehaviorTree. See PlantUML for details.
+//
+// Parameters:
+//   - opts: What to include in the diagram.
+//
+// Returns:
+//   - The PlantUML source of the diagram of the tree's Root.
+func (bt *BehaviorTree) PlantUML(opts DiagramOptions) string {
+	return PlantUML(bt.Root, opts)
+}
+
+// plantUMLQuote returns s as a quoted PlantUML label. PlantUML labels cannot contain double quotes, so they
+// are replaced by single quotes, and line breaks are written as \n.
+func plantUMLQuote(s string) string {
+	s = strings.ReplaceAll(s, `"`, `'`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
@@ -0,0 +1,165 @@
+package behave
+
+import (
+	"errors"
+	"strings"
+)
+
+// Matcher reports whether a node is one of the nodes searched for by Find and FindAll.
+//
+// Parameters:
+//   - node: The node to check.
+//   - ancestors: The nodes above it, from the root of the search down to its parent. The slice is only valid
+//     for the duration of the call.
+//   - path: The path of the node, such as "root/0/2", numbered from the root of the search (see Walk).
+//
+// Returns:
+//   - true if the node matches.
+type Matcher func(node Node, ancestors []Node, path string) bool
+
+// ByName returns a Matcher that matches the nodes with the given name.
+//
+// Parameters:
+//   - name: The name of the nodes to match.
+//
+// Returns:
+//   - A Matcher that matches the nodes whose NodeName is name.
+func ByName(name string) Matcher {
+	return func(node Node, ancestors []Node, path string) bool {
+		return name != "" && NameOf(node) == name
+	}
+}
+
+// ByType returns a Matcher that matches the nodes of type T, such as ByType[*WithTimeout]().
+//
+// Returns:
+//   - A Matcher that matches the nodes of type T.
+func ByType[T Node]() Matcher {
+	return func(node Node, ancestors []Node, path string) bool {
+		_, ok := node.(T)
+		return ok
+	}
+}
+
+// ByPath returns a Matcher that matches the nodes whose location in the tree matches a pattern. The pattern is a
+// list of segments separated by slashes, matched against the nodes from the root of the search down to the node.
+// A segment matches a node if it is the node's name or its index among the children of its parent ("root" for
+// the root of the search), and "*" matches any node. A "**" segment matches any number of nodes, including none.
+//
+// For example, "root/0/2" matches the node with that path, "patrol/approach/*" matches the children of the node
+// named approach below the root named patrol, and "**/approach" matches the nodes named approach anywhere in the
+// tree.
+//
+// Parameters:
+//   - pattern: The pattern to match.
+//
+// Returns:
+//   - A Matcher that matches the nodes whose location matches the pattern.
+func ByPath(pattern string) Matcher {
+	segments := strings.Split(pattern, "/")
+	return func(node Node, ancestors []Node, path string) bool {
+		nodes := append(ancestors[:len(ancestors):len(ancestors)], node)
+		return matchPath(segments, nodes, strings.Split(path, "/"))
+	}
+}
+
+// matchPath reports whether the pattern segments match the nodes, whose own path segments are given in indexes.
+func matchPath(segments []string, nodes []Node, indexes []string) bool {
+	if len(segments) == 0 {
+		return len(nodes) == 0
+	}
+	if segments[0] == "**" {
+		for i := 0; i <= len(nodes); i++ {
+			if matchPath(segments[1:], nodes[i:], indexes[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(nodes) == 0 {
+		return false
+	}
+	if segment := segments[0]; segment != "*" && segment != indexes[0] && segment != NameOf(nodes[0]) {
+		return false
+	}
+	return matchPath(segments[1:], nodes[1:], indexes[1:])
+}
+
+// errFindDone stops the walk of find once the node searched for has been found.
+var errFindDone = errors.New("behave: find done")
+
+// find calls found for every node below root, in PreOrder, that matches m, until found returns false.
+func find(root Node, m Matcher, found func(node Node) bool) {
+	var ancestors []Node
+	_ = Walk(root, PreOrder, func(node Node, depth int, path string) error {
+		ancestors = ancestors[:depth]
+		if (m == nil || m(node, ancestors, path)) && !found(node) {
+			return errFindDone
+		}
+		ancestors = append(ancestors, node)
+		return nil
+	})
+}
+
+// FindAllOf returns the nodes of type T below root that match m, so that they can be inspected or changed without
+// keeping references to them when the tree is built. For example, FindAllOf[*WithTimeout](bt.Root, nil) returns
+// every WithTimeout node of a tree.
+//
+// Parameters:
+//   - root: The root of the subtree to search.
+//   - m: The Matcher the nodes must match, such as ByName("approach"). If nil, every node of type T matches.
+//
+// Returns:
+//   - The matching nodes, in PreOrder (see Walk).
+func FindAllOf[T Node](root Node, m Matcher) []T {
+	var nodes []T
+	find(root, m, func(node Node) bool {
+		if typed, ok := node.(T); ok {
+			nodes = append(nodes, typed)
+		}
+		return true
+	})
+	return nodes
+}
+
+// FindOf returns the first node of type T below root that matches m. See FindAllOf.
+//
+// Parameters:
+//   - root: The root of the subtree to search.
+//   - m: The Matcher the node must match. If nil, every node of type T matches.
+//
+// Returns:
+//   - The first matching node in PreOrder (see Walk).
+//   - true if a node was found, false otherwise.
+func FindOf[T Node](root Node, m Matcher) (T, bool) {
+	var result T
+	ok := false
+	find(root, m, func(node Node) bool {
+		result, ok = node.(T)
+		return !ok
+	})
+	return result, ok
+}
+
+// Find returns the first node of the tree that matches m, such as bt.Find(ByPath("patrol/approach")).
+//
+// Parameters:
+//   - m: The Matcher the node must match.
+//
+// Returns:
+//   - The first matching node in PreOrder (see Walk), or nil if no node matches.
+func (bt *BehaviorTree) Find(m Matcher) Node {
+	node, _ := FindOf[Node](bt.Root, m)
+	return node
+}
+
+// FindAll returns the nodes of the tree that match m, such as bt.FindAll(ByType[*WithTimeout]()).
+//
+// Parameters:
+//   - m: The Matcher the nodes must match.
+//
+// Returns:
+//   - The matching nodes, in PreOrder (see Walk).
+func (bt *BehaviorTree) FindAll(m Matcher) []Node {
+	return FindAllOf[Node](bt.Root, m)
+}
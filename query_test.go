@@ -0,0 +1,87 @@
+package behave
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// queryTestTree returns a patrol tree with named and unnamed nodes for the query tests.
+func queryTestTree() *BehaviorTree {
+	return New(&Sequence{Name: "patrol", Children: []Node{
+		&Selector{Name: "approach", Children: []Node{
+			&WithTimeout{Duration: time.Second, Child: &Action{Name: "walk"}},
+			&WithTimeout{Name: "run", Duration: 2 * time.Second, Child: &Action{Name: "sprint"}},
+		}},
+		&Retry{MaxAttempts: 3, Child: &Action{Name: "walk"}},
+	}})
+}
+
+// queryPaths returns the paths of the nodes found by a query.
+func queryPaths(nodes []Node) []string {
+	paths := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		paths = append(paths, PathOf(node))
+	}
+	return paths
+}
+
+func TestBehaviorTree_FindAll(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Matcher
+		want []string
+	}{
+		{name: "by name", m: ByName("walk"), want: []string{"root/0/0/0", "root/1/0"}},
+		{name: "by type", m: ByType[*WithTimeout](), want: []string{"root/0/0", "root/0/1"}},
+		{name: "children by name path", m: ByPath("patrol/approach/*"), want: []string{"root/0/0", "root/0/1"}},
+		{name: "index path", m: ByPath("root/0/1/0"), want: []string{"root/0/1/0"}},
+		{name: "mixed path", m: ByPath("patrol/0/run"), want: []string{"root/0/1"}},
+		{name: "any depth", m: ByPath("**/walk"), want: []string{"root/0/0/0", "root/1/0"}},
+		{name: "below a node", m: ByPath("patrol/approach/**"), want: []string{"root/0", "root/0/0", "root/0/0/0", "root/0/1", "root/0/1/0"}},
+		{name: "no match", m: ByPath("patrol/retreat/*"), want: []string{}},
+	}
+
+	bt := queryTestTree()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := queryPaths(bt.FindAll(test.m)); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("BehaviorTree.FindAll() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestBehaviorTree_Find(t *testing.T) {
+	bt := queryTestTree()
+	if node := bt.Find(ByName("walk")); PathOf(node) != "root/0/0/0" {
+		t.Errorf("BehaviorTree.Find() = %v, want the first node named walk", node)
+	}
+	if node := bt.Find(ByName("fly")); node != nil {
+		t.Errorf("BehaviorTree.Find() = %v, want nil", node)
+	}
+}
+
+func TestFindOf(t *testing.T) {
+	bt := queryTestTree()
+
+	// A typed handle allows the node to be changed in place
+	run, ok := FindOf[*WithTimeout](bt.Root, ByName("run"))
+	if !ok {
+		t.Fatalf("FindOf[*WithTimeout]() found no node named run")
+	}
+	run.Duration = 5 * time.Second
+	if timeout := bt.Root.(*Sequence).Children[0].(*Selector).Children[1].(*WithTimeout); timeout.Duration != 5*time.Second {
+		t.Errorf("WithTimeout.Duration = %v, want the duration set through the handle", timeout.Duration)
+	}
+
+	if _, ok := FindOf[*Retry](bt.Root, ByName("run")); ok {
+		t.Errorf("FindOf[*Retry]() should not return a node of another type")
+	}
+	if retry, ok := FindOf[*Retry](bt.Root, nil); !ok || retry.MaxAttempts != 3 {
+		t.Errorf("FindOf[*Retry]() = %v, %v, want the Retry node", retry, ok)
+	}
+	if actions := FindAllOf[*Action](bt.Root, ByPath("patrol/approach/**")); len(actions) != 2 || actions[1].Name != "sprint" {
+		t.Errorf("FindAllOf[*Action]() = %v, want the walk and sprint actions", actions)
+	}
+}
@@ -0,0 +1,277 @@
+package behave
+
+import (
+	"context"
+	"strings"
+)
+
+// ReactiveSequence is a Node that runs its children in order and succeeds if all children succeed.
+// Unlike Sequence, it does not remember which children have already succeeded: every tick starts again at the
+// first child, so a guard condition early in the sequence can interrupt a Running child later on. When an
+// earlier child fails or is Running, the child that was previously Running is halted.
+type ReactiveSequence struct {
+	Name     string // Optional name that identifies the node in String(), logs and tooling
+	Children []Node
+	status   Status
+	running  int    // One more than the index of the child that was Running after the last tick, or 0 if none
+	path     string // Path of the node in its tree, assigned by AssignPaths
+}
+
+// Tick executes the ReactiveSequence node with a background context.
+//
+// Returns:
+//   - The status of the ReactiveSequence node after execution. See TickContext for details.
+func (rs *ReactiveSequence) Tick() Status {
+	return rs.TickContext(context.Background())
+}
+
+// TickContext runs the sequence from its first child and handles all status values.
+//
+// Parameters:
+//   - ctx: The context for this tick. It is passed to the children of the node.
+//     If ctx is cancelled, the node fails without ticking its children.
+//
+// Returns:
+//   - The status of the ReactiveSequence node after execution, which can be Ready, Running, Success, or Failure.
+func (rs *ReactiveSequence) TickContext(ctx context.Context) Status {
+	if ctx.Err() != nil {
+		rs.status = Failure
+		return rs.status
+	}
+
+	for i, child := range rs.Children {
+		status := TickNode(ctx, child)
+		switch status {
+		case Success:
+			continue
+		case Ready, Running, Failure:
+			// An earlier child changed its outcome, so halt the child that was previously running
+			haltPreempted(rs.Children, rs.running, i)
+			rs.running = 0
+			if status == Running {
+				rs.running = i + 1
+			}
+			rs.status = status
+			return rs.status
+		default:
+			rs.running = 0
+			rs.status = Failure
+			return rs.status
+		}
+	}
+	rs.running = 0
+	rs.status = Success
+	return rs.status
+}
+
+// Reset resets the ReactiveSequence node and all its children to their initial state.
+//
+// Returns:
+//   - The status of the ReactiveSequence node after reset, which will be Ready. This method resets all child nodes
+//     to their initial state.
+func (rs *ReactiveSequence) Reset() Status {
+	for _, child := range rs.Children {
+		child.Reset()
+	}
+	rs.running = 0
+	rs.status = Ready
+	return rs.status
+}
+
+// Halt interrupts the ReactiveSequence node and all its children.
+//
+// Returns:
+//   - The status of the ReactiveSequence node after halting, which will be Ready. Running child nodes are halted,
+//     while all others are reset.
+func (rs *ReactiveSequence) Halt() Status {
+	for _, child := range rs.Children {
+		HaltNode(child)
+	}
+	rs.running = 0
+	rs.status = Ready
+	return rs.status
+}
+
+// Status returns the current status of the ReactiveSequence node.
+//
+// Returns:
+//   - The current status of the ReactiveSequence node, which can be Ready, Running, Success, or Failure.
+func (rs *ReactiveSequence) Status() Status {
+	return rs.status
+}
+
+// String returns a string representation of the ReactiveSequence node.
+//
+// Returns:
+//   - A string that represents the ReactiveSequence node, including its current status and all child nodes.
+//     The format is: ReactiveSequence (Status), with the name and path of the node after its type when they are set.
+func (rs *ReactiveSequence) String() string {
+	var builder strings.Builder
+	builder.WriteString(nodeLabel("ReactiveSequence", rs.Name, rs.path) + " (" + rs.Status().String() + ")")
+	for _, child := range rs.Children {
+		str := child.String()
+		lines := strings.Split(str, "\n")
+		for _, line := range lines {
+			builder.WriteString("\n  ")
+			builder.WriteString(line)
+		}
+	}
+	return builder.String()
+}
+
+// NodeName returns the optional name of the ReactiveSequence node.
+//
+// Returns:
+//   - The Name of the node, or the empty string if it has none.
+func (rs *ReactiveSequence) NodeName() string {
+	return rs.Name
+}
+
+// Path returns the path of the ReactiveSequence node in its tree, such as "root/0/2".
+//
+// Returns:
+//   - The path assigned by AssignPaths, or the empty string if none has been assigned.
+func (rs *ReactiveSequence) Path() string {
+	return rs.path
+}
+
+// SetPath sets the path of the ReactiveSequence node in its tree. It is called by AssignPaths.
+//
+// Parameters:
+//   - path: The path of the node.
+func (rs *ReactiveSequence) SetPath(path string) {
+	rs.path = path
+}
+
+// ChildNodes returns the children of the ReactiveSequence node.
+//
+// Returns:
+//   - The Children of the node, in order.
+func (rs *ReactiveSequence) ChildNodes() []Node {
+	return rs.Children
+}
+
+// ReactiveSelector is a Node that runs its children in order and succeeds if at least one child succeeds.
+// Every tick starts again at the first child, so a higher-priority child can take over from a lower-priority
+// child that is Running. When that happens, the lower-priority child is halted.
+//
+// ReactiveSelector behaves the same as Selector, which also re-evaluates its children from the first one on every
+// tick; both are ticked by the same code. It is the counterpart of ReactiveSequence, for trees that name their
+// reactive nodes explicitly.
+type ReactiveSelector struct {
+	Name     string // Optional name that identifies the node in String(), logs and tooling
+	Children []Node
+	status   Status
+	running  int    // One more than the index of the child that was Running after the last tick, or 0 if none
+	path     string // Path of the node in its tree, assigned by AssignPaths
+}
+
+// Tick executes the ReactiveSelector node with a background context.
+//
+// Returns:
+//   - The status of the ReactiveSelector node after execution. See TickContext for details.
+func (rs *ReactiveSelector) Tick() Status {
+	return rs.TickContext(context.Background())
+}
+
+// TickContext runs the selector from its first child and handles all status values.
+//
+// Parameters:
+//   - ctx: The context for this tick. It is passed to the children of the node.
+//     If ctx is cancelled, the node fails without ticking its children.
+//
+// Returns:
+//   - The status of the ReactiveSelector node after execution, which can be Ready, Running, Success, or Failure.
+//     The ReactiveSelector returns Success if at least one child returns Success, Running if at least one child
+//     is Running and none before it have succeeded, and Failure if all children have failed.
+func (rs *ReactiveSelector) TickContext(ctx context.Context) Status {
+	rs.status = tickSelector(ctx, rs.Children, &rs.running)
+	return rs.status
+}
+
+// Reset resets the ReactiveSelector node and all its children to their initial state.
+//
+// Returns:
+//   - The status of the ReactiveSelector node after reset, which will be Ready. This method resets all child nodes
+//     to their initial state.
+func (rs *ReactiveSelector) Reset() Status {
+	for _, child := range rs.Children {
+		child.Reset()
+	}
+	rs.running = 0
+	rs.status = Ready
+	return rs.status
+}
+
+// Halt interrupts the ReactiveSelector node and all its children.
+//
+// Returns:
+//   - The status of the ReactiveSelector node after halting, which will be Ready. Running child nodes are halted,
+//     while all others are reset.
+func (rs *ReactiveSelector) Halt() Status {
+	for _, child := range rs.Children {
+		HaltNode(child)
+	}
+	rs.running = 0
+	rs.status = Ready
+	return rs.status
+}
+
+// Status returns the current status of the ReactiveSelector node.
+//
+// Returns:
+//   - The current status of the ReactiveSelector node, which can be Ready, Running, Success, or Failure.
+func (rs *ReactiveSelector) Status() Status {
+	return rs.status
+}
+
+// String returns a string representation of the ReactiveSelector node.
+//
+// Returns:
+//   - A string that represents the ReactiveSelector node, including its current status and all child nodes.
+//     The format is: ReactiveSelector (Status), with the name and path of the node after its type when they are set.
+func (rs *ReactiveSelector) String() string {
+	var builder strings.Builder
+	builder.WriteString(nodeLabel("ReactiveSelector", rs.Name, rs.path) + " (" + rs.Status().String() + ")")
+	for _, child := range rs.Children {
+		str := child.String()
+		lines := strings.Split(str, "\n")
+		for _, line := range lines {
+			builder.WriteString("\n  ")
+			builder.WriteString(line)
+		}
+	}
+	return builder.String()
+}
+
+// NodeName returns the optional name of the ReactiveSelector node.
+//
+// Returns:
+//   - The Name of the node, or the empty string if it has none.
+func (rs *ReactiveSelector) NodeName() string {
+	return rs.Name
+}
+
+// Path returns the path of the ReactiveSelector node in its tree, such as "root/0/2".
+//
+// Returns:
+//   - The path assigned by AssignPaths, or the empty string if none has been assigned.
+func (rs *ReactiveSelector) Path() string {
+	return rs.path
+}
+
+// SetPath sets the path of the ReactiveSelector node in its tree. It is called by AssignPaths.
+//
+// Parameters:
+//   - path: The path of the node.
+func (rs *ReactiveSelector) SetPath(path string) {
+	rs.path = path
+}
+
+// ChildNodes returns the children of the ReactiveSelector node.
+//
+// Returns:
+//   - The Children of the node, in order.
+func (rs *ReactiveSelector) ChildNodes() []Node {
+	return rs.Children
+}
@@ -0,0 +1,228 @@
+package behave
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReactiveSequence_Tick(t *testing.T) {
+	tests := []struct {
+		name     string
+		children []Node
+		expected Status
+	}{
+		{
+			name:     "empty sequence",
+			children: []Node{},
+			expected: Success,
+		},
+		{
+			name: "all success",
+			children: []Node{
+				&Action{Run: func() Status { return Success }},
+				&Action{Run: func() Status { return Success }},
+			},
+			expected: Success,
+		},
+		{
+			name: "second fails",
+			children: []Node{
+				&Action{Run: func() Status { return Success }},
+				&Action{Run: func() Status { return Failure }},
+			},
+			expected: Failure,
+		},
+		{
+			name: "second running",
+			children: []Node{
+				&Action{Run: func() Status { return Success }},
+				&Action{Run: func() Status { return Running }},
+			},
+			expected: Running,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sequence := &ReactiveSequence{Children: test.children}
+			status := sequence.Tick()
+			if status != test.expected {
+				t.Errorf("ReactiveSequence.Tick() = %v, want %v", status, test.expected)
+			}
+			if sequence.Status() != test.expected {
+				t.Errorf("ReactiveSequence.Status() after Tick() = %v, want %v", sequence.Status(), test.expected)
+			}
+		})
+	}
+}
+
+func TestReactiveSequence_GuardInterruptsRunningChild(t *testing.T) {
+	guard := true
+	guardChecks := 0
+	halted := 0
+	sequence := &ReactiveSequence{Children: []Node{
+		&Condition{Check: func() bool {
+			guardChecks++
+			return guard
+		}},
+		&Action{Run: func() Status { return Success }},
+		&Action{Run: func() Status { return Running }, OnHalt: func() { halted++ }},
+	}}
+
+	for i := 0; i < 3; i++ {
+		if status := sequence.Tick(); status != Running {
+			t.Errorf("ReactiveSequence.Tick() call %d = %v, want %v", i+1, status, Running)
+		}
+	}
+	if guardChecks != 3 {
+		t.Errorf("Guard checked %d times, want 3 (once per tick)", guardChecks)
+	}
+
+	guard = false
+	if status := sequence.Tick(); status != Failure {
+		t.Errorf("ReactiveSequence.Tick() after guard fails = %v, want %v", status, Failure)
+	}
+	if halted != 1 {
+		t.Errorf("OnHalt called %d times, want 1", halted)
+	}
+
+	// A plain Sequence never re-checks the guard
+	guard = true
+	guardChecks = 0
+	plain := &Sequence{Children: []Node{
+		&Condition{Check: func() bool {
+			guardChecks++
+			return guard
+		}},
+		&Action{Run: func() Status { return Running }},
+	}}
+	plain.Tick()
+	guard = false
+	if status := plain.Tick(); status != Running {
+		t.Errorf("Sequence.Tick() after guard fails = %v, want %v", status, Running)
+	}
+	if guardChecks != 1 {
+		t.Errorf("Sequence checked the guard %d times, want 1", guardChecks)
+	}
+}
+
+func TestReactiveSelector_Tick(t *testing.T) {
+	tests := []struct {
+		name     string
+		children []Node
+		expected Status
+	}{
+		{
+			name:     "empty selector",
+			children: []Node{},
+			expected: Failure,
+		},
+		{
+			name: "second succeeds",
+			children: []Node{
+				&Action{Run: func() Status { return Failure }},
+				&Action{Run: func() Status { return Success }},
+			},
+			expected: Success,
+		},
+		{
+			name: "all fail",
+			children: []Node{
+				&Action{Run: func() Status { return Failure }},
+				&Action{Run: func() Status { return Failure }},
+			},
+			expected: Failure,
+		},
+		{
+			name: "second running",
+			children: []Node{
+				&Action{Run: func() Status { return Failure }},
+				&Action{Run: func() Status { return Running }},
+			},
+			expected: Running,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			selector := &ReactiveSelector{Children: test.children}
+			status := selector.Tick()
+			if status != test.expected {
+				t.Errorf("ReactiveSelector.Tick() = %v, want %v", status, test.expected)
+			}
+			if selector.Status() != test.expected {
+				t.Errorf("ReactiveSelector.Status() after Tick() = %v, want %v", selector.Status(), test.expected)
+			}
+		})
+	}
+}
+
+func TestReactiveSelector_HigherPriorityTakesOver(t *testing.T) {
+	// ReactiveSelector behaves the same as Selector
+	selectors := map[string]func(children []Node) Node{
+		"ReactiveSelector": func(children []Node) Node { return &ReactiveSelector{Children: children} },
+		"Selector":         func(children []Node) Node { return &Selector{Children: children} },
+	}
+	for name, newSelector := range selectors {
+		t.Run(name, func(t *testing.T) {
+			highPriority := Failure
+			halted := 0
+			selector := newSelector([]Node{
+				&Action{Run: func() Status { return highPriority }},
+				&Action{Run: func() Status { return Running }, OnHalt: func() { halted++ }},
+			})
+
+			if status := selector.Tick(); status != Running {
+				t.Errorf("%s.Tick() = %v, want %v", name, status, Running)
+			}
+
+			// The high-priority branch starts running, so the low-priority branch is halted
+			highPriority = Running
+			if status := selector.Tick(); status != Running {
+				t.Errorf("%s.Tick() = %v, want %v", name, status, Running)
+			}
+			if halted != 1 {
+				t.Errorf("OnHalt called %d times, want 1", halted)
+			}
+
+			highPriority = Success
+			if status := selector.Tick(); status != Success {
+				t.Errorf("%s.Tick() = %v, want %v", name, status, Success)
+			}
+			if halted != 1 {
+				t.Errorf("OnHalt called %d times, want 1", halted)
+			}
+		})
+	}
+}
+
+func TestReactive_ResetHaltString(t *testing.T) {
+	halted := 0
+	sequence := &ReactiveSequence{Children: []Node{
+		&Action{Run: func() Status { return Running }, OnHalt: func() { halted++ }},
+	}}
+	selector := &ReactiveSelector{Children: []Node{sequence}}
+
+	selector.Tick()
+	if status := selector.Halt(); status != Ready {
+		t.Errorf("ReactiveSelector.Halt() = %v, want %v", status, Ready)
+	}
+	if halted != 1 {
+		t.Errorf("OnHalt called %d times, want 1", halted)
+	}
+
+	selector.Tick()
+	if status := selector.Reset(); status != Ready {
+		t.Errorf("ReactiveSelector.Reset() = %v, want %v", status, Ready)
+	}
+	if sequence.Status() != Ready {
+		t.Errorf("ReactiveSequence.Status() after Reset() = %v, want %v", sequence.Status(), Ready)
+	}
+
+	str := selector.String()
+	for _, expected := range []string{"ReactiveSelector (Ready)", "\n  ReactiveSequence (Ready)", "\n    Action (Ready)"} {
+		if !strings.Contains(str, expected) {
+			t.Errorf("ReactiveSelector.String() should contain %q, got %v", expected, str)
+		}
+	}
+}
@@ -0,0 +1,366 @@
+package behave
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+)
+
+// defaultRecorderCapacity is the number of ticks a Recorder keeps when RecorderOptions.Capacity is not set.
+const defaultRecorderCapacity = 100
+
+// TraceEvent is a node enter or exit event recorded by a Recorder.
+type TraceEvent struct {
+	Kind     string        `json:"kind"`               // "enter" or "exit"
+	Path     string        `json:"path"`               // Path of the node in its tree, such as "root/0/2"
+	Name     string        `json:"name,omitempty"`     // Name of the node, if it has one
+	Type     string        `json:"type"`               // Type of the node, such as "Sequence"
+	Previous Status        `json:"previous"`           // Status of the node before the tick
+	Status   Status        `json:"status"`             // Status of the node after the tick, or Previous in an enter event
+	Time     time.Time     `json:"time"`               // Time at which the node was entered or exited
+	Duration time.Duration `json:"duration,omitempty"` // Time the tick of the node took, in nanoseconds. Zero in an enter event
+}
+
+// MarshalJSON encodes the TraceEvent, writing its statuses by name, such as "Success".
+//
+// Returns:
+//   - The JSON encoding of the event.
+//   - An error if a status is not one of Ready, Running, Success or Failure.
+func (e TraceEvent) MarshalJSON() ([]byte, error) {
+	type plain TraceEvent
+	return json.Marshal(struct {
+		plain
+		Previous traceStatus `json:"previous"`
+		Status   traceStatus `json:"status"`
+	}{plain(e), traceStatus(e.Previous), traceStatus(e.Status)})
+}
+
+// UnmarshalJSON decodes a TraceEvent written by MarshalJSON.
+//
+// Parameters:
+//   - data: The JSON encoding of the event.
+//
+// Returns:
+//   - An error if the data is not a valid event.
+func (e *TraceEvent) UnmarshalJSON(data []byte) error {
+	type plain TraceEvent
+	var decoded struct {
+		plain
+		Previous traceStatus `json:"previous"`
+		Status   traceStatus `json:"status"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*e = TraceEvent(decoded.plain)
+	e.Previous, e.Status = Status(decoded.Previous), Status(decoded.Status)
+	return nil
+}
+
+// BlackboardChange is a change to an entry of the Blackboard made during a tick.
+type BlackboardChange struct {
+	Key     string `json:"key"`               // Key of the entry
+	Value   any    `json:"value,omitempty"`   // New value of the entry, unless it was deleted
+	Deleted bool   `json:"deleted,omitempty"` // Whether the entry was deleted
+}
+
+// TickRecord is the trace of a single tick of a tree, as recorded by a Recorder.
+type TickRecord struct {
+	Tick       int                `json:"tick"`                 // Number of the tick, counted from 1 since the Recorder was created
+	Start      time.Time          `json:"start"`                // Time at which the tick started
+	Duration   time.Duration      `json:"duration"`             // Time the tick took, in nanoseconds
+	Status     Status             `json:"status"`               // Status of the tree after the tick
+	Events     []TraceEvent       `json:"events"`               // Enter and exit events of every node ticked, in order
+	Blackboard []BlackboardChange `json:"blackboard,omitempty"` // Changes to the Blackboard, sorted by key, if recorded
+}
+
+// MarshalJSON encodes the TickRecord, writing its statuses by name, such as "Success".
+//
+// Returns:
+//   - The JSON encoding of the tick.
+//   - An error if a status is not one of Ready, Running, Success or Failure.
+func (t TickRecord) MarshalJSON() ([]byte, error) {
+	type plain TickRecord
+	return json.Marshal(struct {
+		plain
+		Status traceStatus `json:"status"`
+	}{plain(t), traceStatus(t.Status)})
+}
+
+// UnmarshalJSON decodes a TickRecord written by MarshalJSON.
+//
+// Parameters:
+//   - data: The JSON encoding of the tick.
+//
+// Returns:
+//   - An error if the data is not a valid tick.
+func (t *TickRecord) UnmarshalJSON(data []byte) error {
+	type plain TickRecord
+	var decoded struct {
+		plain
+		Status traceStatus `json:"status"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*t = TickRecord(decoded.plain)
+	t.Status = Status(decoded.Status)
+	return nil
+}
+
+// traceStatus is a Status as written in a trace: by name, so that traces are readable and don't depend on the
+// numbering of the Status values.
+type traceStatus Status
+
+// MarshalText encodes the status as its name.
+func (s traceStatus) MarshalText() ([]byte, error) {
+	if Status(s) < Ready || Status(s) > Failure {
+		return nil, fmt.Errorf("behave: invalid status %d", int(s))
+	}
+	return []byte(Status(s).String()), nil
+}
+
+// UnmarshalText decodes a status from its name.
+func (s *traceStatus) UnmarshalText(text []byte) error {
+	for status := Ready; status <= Failure; status++ {
+		if string(text) == status.String() {
+			*s = traceStatus(status)
+			return nil
+		}
+	}
+	return fmt.Errorf("behave: unknown status %q", text)
+}
+
+// RecorderOptions configures a Recorder.
+type RecorderOptions struct {
+	Capacity   int       // Number of ticks kept in memory; older ticks are dropped. If zero, 100 ticks are kept
+	Writer     io.Writer // Optional writer every tick is written to as a line of JSON as soon as it completes
+	Blackboard bool      // If true, the changes a tick makes to the tree's Blackboard are recorded
+}
+
+// Recorder records the execution of a tree tick by tick for postmortems: the enter and exit events of every node,
+// with their statuses and timestamps, and optionally the changes made to the Blackboard. The most recent ticks are
+// kept in a ring buffer, and every tick can also be written to a file as newline-delimited JSON (NDJSON).
+//
+// A Recorder is an Observer, so recording needs no change to the nodes of the tree; use Attach to record a
+// BehaviorTree. The ticks of a tree must not overlap, but the nodes of a tick can be ticked concurrently, as
+// ConcurrentParallel does. A Recorder is safe for concurrent use.
+type Recorder struct {
+	mu       sync.Mutex
+	options  RecorderOptions
+	tree     *BehaviorTree  // The tree whose Blackboard is recorded, if attached
+	ticks    []TickRecord   // Ring buffer of the most recent ticks
+	next     int            // Index in ticks at which the next tick is stored once the buffer is full
+	count    int            // Number of ticks recorded so far
+	depth    int            // Number of nodes entered but not yet exited in the current tick
+	current  *TickRecord    // The tick being recorded, or nil between ticks
+	snapshot map[string]any // Entries of the Blackboard when the current tick started
+	board    *Blackboard    // Blackboard the snapshot was taken of, so that the tick is recorded even if detached
+	encoder  *json.Encoder  // Encoder writing to options.Writer, if set
+	err      error          // First error returned by options.Writer
+}
+
+// NewRecorder creates a new Recorder.
+//
+// Parameters:
+//   - options: How much to keep and where to write the recorded ticks.
+//
+// Returns:
+//   - A pointer to a new Recorder instance with no recorded ticks.
+func NewRecorder(options RecorderOptions) *Recorder {
+	if options.Capacity <= 0 {
+		options.Capacity = defaultRecorderCapacity
+	}
+	r := &Recorder{options: options}
+	if options.Writer != nil {
+		r.encoder = json.NewEncoder(options.Writer)
+	}
+	return r
+}
+
+// Attach starts recording the ticks of a BehaviorTree, including the changes to its Blackboard if
+// RecorderOptions.Blackboard is set.
+//
+// Parameters:
+//   - bt: The tree to record.
+//
+// Returns:
+//   - A function that stops recording the tree.
+func (r *Recorder) Attach(bt *BehaviorTree) func() {
+	r.mu.Lock()
+	r.tree = bt
+	r.mu.Unlock()
+	remove := bt.AddObserver(r)
+	return func() {
+		remove()
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if r.tree == bt {
+			r.tree = nil
+		}
+	}
+}
+
+// OnEnter records a node enter event. A tick starts when its first node, the root, is entered.
+//
+// Parameters:
+//   - event: The event describing the node about to be ticked.
+func (r *Recorder) OnEnter(event TickEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.depth == 0 {
+		r.count++
+		r.current = &TickRecord{Tick: r.count, Start: event.Start}
+		if r.options.Blackboard && r.tree != nil && r.tree.Blackboard != nil {
+			r.board = r.tree.Blackboard
+			r.snapshot = blackboardSnapshot(r.board)
+		}
+	}
+	r.depth++
+	r.current.Events = append(r.current.Events, traceEvent("enter", event))
+}
+
+// OnExit records a node exit event. A tick ends when its root is exited, and is then added to the ring buffer
+// and written to RecorderOptions.Writer.
+//
+// Parameters:
+//   - event: The event describing the node that was ticked.
+func (r *Recorder) OnExit(event TickEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.current == nil {
+		return // An exit without a matching enter, such as that of a tick started before the Recorder was added
+	}
+	r.current.Events = append(r.current.Events, traceEvent("exit", event))
+	r.depth--
+	if r.depth > 0 {
+		return
+	}
+
+	record := *r.current
+	record.Duration = event.Duration
+	record.Status = event.Status
+	if r.snapshot != nil {
+		record.Blackboard = blackboardChanges(r.snapshot, blackboardSnapshot(r.board))
+		r.snapshot, r.board = nil, nil
+	}
+	r.current = nil
+
+	if len(r.ticks) < r.options.Capacity {
+		r.ticks = append(r.ticks, record)
+	} else {
+		r.ticks[r.next] = record
+		r.next = (r.next + 1) % r.options.Capacity
+	}
+	if r.encoder != nil && r.err == nil {
+		r.err = r.encoder.Encode(record)
+	}
+}
+
+// Ticks returns the ticks kept in the ring buffer.
+//
+// Returns:
+//   - The most recent ticks, oldest first.
+func (r *Recorder) Ticks() []TickRecord {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ticks := make([]TickRecord, 0, len(r.ticks))
+	ticks = append(ticks, r.ticks[r.next:]...)
+	return append(ticks, r.ticks[:r.next]...)
+}
+
+// WriteNDJSON writes the ticks kept in the ring buffer as newline-delimited JSON, one tick per line.
+//
+// Parameters:
+//   - w: The writer to write the ticks to.
+//
+// Returns:
+//   - An error if a tick cannot be encoded or written.
+func (r *Recorder) WriteNDJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	for _, record := range r.Ticks() {
+		if err := encoder.Encode(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Err returns the first error that occurred while writing a tick to RecorderOptions.Writer. Once an error has
+// occurred, no more ticks are written, but ticks are still kept in the ring buffer.
+//
+// Returns:
+//   - The first write error, or nil if there was none.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// ReadTrace reads ticks written as newline-delimited JSON by a Recorder. Blackboard values are decoded as the
+// generic JSON types (float64, string, bool, []any and map[string]any).
+//
+// Parameters:
+//   - reader: The reader to read the ticks from.
+//
+// Returns:
+//   - The ticks, in the order in which they were written.
+//   - An error if a line is not a valid tick.
+func ReadTrace(reader io.Reader) ([]TickRecord, error) {
+	var ticks []TickRecord
+	decoder := json.NewDecoder(bufio.NewReader(reader))
+	for decoder.More() {
+		var record TickRecord
+		if err := decoder.Decode(&record); err != nil {
+			return nil, err
+		}
+		ticks = append(ticks, record)
+	}
+	return ticks, nil
+}
+
+// traceEvent converts a TickEvent into the TraceEvent recorded for it.
+func traceEvent(kind string, event TickEvent) TraceEvent {
+	return TraceEvent{
+		Kind:     kind,
+		Path:     event.Path,
+		Name:     event.Name,
+		Type:     typeName(event.Node),
+		Previous: event.Previous,
+		Status:   event.Status,
+		Time:     event.Start.Add(event.Duration),
+		Duration: event.Duration,
+	}
+}
+
+// blackboardSnapshot returns a copy of the entries of the Blackboard.
+func blackboardSnapshot(bb *Blackboard) map[string]any {
+	snapshot := map[string]any{}
+	bb.Range(func(key string, value any) bool {
+		snapshot[key] = value
+		return true
+	})
+	return snapshot
+}
+
+// blackboardChanges returns the changes between two snapshots of a Blackboard, sorted by key.
+func blackboardChanges(before, after map[string]any) []BlackboardChange {
+	var changes []BlackboardChange
+	for key, value := range after {
+		if old, ok := before[key]; !ok || !reflect.DeepEqual(old, value) {
+			changes = append(changes, BlackboardChange{Key: key, Value: value})
+		}
+	}
+	for key := range before {
+		if _, ok := after[key]; !ok {
+			changes = append(changes, BlackboardChange{Key: key, Deleted: true})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes
+}
@@ -0,0 +1,144 @@
+package behave
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecorder(t *testing.T) {
+	clock := NewFakeClock(time.Unix(100, 0).UTC())
+	bt := New(&ReactiveSequence{Name: "patrol", Children: []Node{
+		&Condition{Name: "armed", CheckWithBlackboard: func(bb *Blackboard) bool { return !bb.Has("stop") }},
+		&Action{Name: "step", RunWithBlackboard: func(bb *Blackboard) Status {
+			clock.Advance(time.Second)
+			steps, _ := bb.GetInt("steps")
+			bb.Set("steps", steps+1)
+			bb.Delete("target")
+			return Running
+		}},
+	}})
+	bt.Clock = clock
+	bt.Blackboard.Set("target", "door")
+
+	var out bytes.Buffer
+	recorder := NewRecorder(RecorderOptions{Capacity: 2, Writer: &out, Blackboard: true})
+	detach := recorder.Attach(bt)
+	for i := 0; i < 3; i++ {
+		bt.Tick()
+	}
+	detach()
+	bt.Tick()
+	if recorder.tree != nil {
+		t.Errorf("Recorder still refers to the tree after it was detached")
+	}
+
+	ticks := recorder.Ticks()
+	if len(ticks) != 2 || ticks[0].Tick != 2 || ticks[1].Tick != 3 {
+		t.Fatalf("Recorder.Ticks() = %d ticks, want ticks 2 and 3 of a ring buffer of 2", len(ticks))
+	}
+	last := ticks[1]
+	if last.Status != Running || last.Duration != time.Second || !last.Start.Equal(time.Unix(102, 0)) {
+		t.Errorf("last tick = %v at %v for %v, want Running at %v for 1s", last.Status, last.Start, last.Duration, time.Unix(102, 0))
+	}
+
+	var events []string
+	for _, event := range last.Events {
+		events = append(events, strings.Join([]string{event.Kind, event.Path, event.Type, event.Name, event.Previous.String(), event.Status.String()}, " "))
+	}
+	want := []string{
+		"enter root ReactiveSequence patrol Running Running",
+		"enter root/0 Condition armed Ready Ready",
+		"exit root/0 Condition armed Ready Success",
+		"enter root/1 Action step Running Running",
+		"exit root/1 Action step Running Running",
+		"exit root ReactiveSequence patrol Running Running",
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("recorded events\n%q\nwant\n%q", events, want)
+	}
+	if exit := last.Events[4]; exit.Duration != time.Second || !exit.Time.Equal(time.Unix(103, 0)) {
+		t.Errorf("exit event of the action = %v at %v, want 1s at %v", exit.Duration, exit.Time, time.Unix(103, 0))
+	}
+
+	if changes := ticks[1].Blackboard; !reflect.DeepEqual(changes, []BlackboardChange{{Key: "steps", Value: 3}}) {
+		t.Errorf("blackboard changes of tick 3 = %v, want steps set to 3", changes)
+	}
+	written, err := ReadTrace(&out)
+	if err != nil {
+		t.Fatalf("ReadTrace() error = %v", err)
+	}
+	if len(written) != 3 || recorder.Err() != nil {
+		t.Fatalf("Recorder wrote %d ticks with error %v, want 3 ticks", len(written), recorder.Err())
+	}
+	first := written[0].Blackboard
+	if !reflect.DeepEqual(first, []BlackboardChange{{Key: "steps", Value: 1.0}, {Key: "target", Deleted: true}}) {
+		t.Errorf("written blackboard changes of tick 1 = %v, want steps set and target deleted", first)
+	}
+	if !reflect.DeepEqual(written[2].Events, last.Events) {
+		t.Errorf("written events of tick 3 = %v, want %v", written[2].Events, last.Events)
+	}
+}
+
+func TestRecorder_DetachDuringTick(t *testing.T) {
+	var detach func()
+	bt := New(&Action{RunWithBlackboard: func(bb *Blackboard) Status {
+		detach()
+		bb.Set("door", "open")
+		return Success
+	}})
+	recorder := NewRecorder(RecorderOptions{Blackboard: true})
+	detach = recorder.Attach(bt)
+	bt.Tick()
+
+	ticks := recorder.Ticks()
+	if len(ticks) != 1 || !reflect.DeepEqual(ticks[0].Blackboard, []BlackboardChange{{Key: "door", Value: "open"}}) {
+		t.Errorf("Recorder.Ticks() = %v, want the tick that detached the recorder with its blackboard changes", ticks)
+	}
+}
+
+func TestRecorder_WriteNDJSON(t *testing.T) {
+	recorder := NewRecorder(RecorderOptions{})
+	bt := New(&Action{Run: func() Status { return Success }})
+	bt.AddObserver(recorder)
+	bt.Tick()
+	bt.Tick()
+
+	var out bytes.Buffer
+	if err := recorder.WriteNDJSON(&out); err != nil {
+		t.Fatalf("Recorder.WriteNDJSON() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[1], `"status":"Success"`) {
+		t.Errorf("Recorder.WriteNDJSON() = %s, want 2 lines with named statuses", out.String())
+	}
+	if ticks := recorder.Ticks(); ticks[0].Blackboard != nil {
+		t.Errorf("blackboard changes = %v, want none without RecorderOptions.Blackboard", ticks[0].Blackboard)
+	}
+}
+
+func TestTraceEvent_JSON(t *testing.T) {
+	data, err := json.Marshal(TraceEvent{Kind: "exit", Previous: Running, Status: Failure})
+	if err != nil || !strings.Contains(string(data), `"previous":"Running","status":"Failure"`) {
+		t.Errorf("json.Marshal() = %s, %v, want named statuses", data, err)
+	}
+	var event TraceEvent
+	if err := json.Unmarshal(data, &event); err != nil || event.Previous != Running || event.Status != Failure || event.Kind != "exit" {
+		t.Errorf("json.Unmarshal() = %+v, %v, want the event back", event, err)
+	}
+	var record TickRecord
+	if err := json.Unmarshal([]byte(`{"tick":1,"status":"Done"}`), &record); err == nil {
+		t.Errorf("json.Unmarshal() of an unknown status should return an error")
+	}
+	if _, err := json.Marshal(TickRecord{Status: Status(7)}); err == nil {
+		t.Errorf("json.Marshal() of an invalid status should return an error")
+	}
+
+	// Outside of traces, a Status is still encoded as a number
+	if data, err := json.Marshal(Success); err != nil || string(data) != "2" {
+		t.Errorf("json.Marshal(Success) = %s, %v, want 2", data, err)
+	}
+}
@@ -0,0 +1,456 @@
+package behave
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// NodeType describes how a Registry creates the nodes of one type from their Definition.
+type NodeType struct {
+	New         func(def *Definition, children []Node) (Node, error) // Creates the node from its definition and already-built children
+	MinChildren int                                                  // Minimum number of children
+	MaxChildren int                                                  // Maximum number of children. If negative, there is no limit
+	Required    []string                                             // Parameters that must be set
+}
+
+// leafType is the function a Registry uses to create a named leaf node, along with the kind of leaf it creates.
+type leafType struct {
+	kind   string // "Action", "Condition" or "AsyncAction", or empty for a leaf of any kind (see Definition.Build)
+	create func() Node
+}
+
+// Registry maps the names used by tree definitions to the functions that create nodes. A new Registry knows
+// every built-in node type; leaf nodes and custom node types are added by registering them. Once "OpenDoor"
+// has been registered as an Action, any tree definition can refer to it as {"type": "Action", "name": "OpenDoor"}.
+//
+// A Registry is safe for concurrent use.
+type Registry struct {
+	mu     sync.RWMutex
+	types  map[string]NodeType
+	leaves map[string]leafType
+}
+
+// NewRegistry creates a new Registry that knows every built-in node type.
+//
+// Returns:
+//   - A pointer to a new Registry instance.
+func NewRegistry() *Registry {
+	r := &Registry{types: map[string]NodeType{}, leaves: map[string]leafType{}}
+	for name, nodeType := range builtinTypes {
+		r.types[name] = nodeType
+	}
+	return r
+}
+
+// RegisterType adds a custom node type to the Registry. For trees that contain nodes of the type to be saved
+// as well as loaded, the nodes must implement Describer.
+//
+// Parameters:
+//   - name: The name tree definitions use as the type of the node.
+//   - nodeType: How nodes of the type are created.
+//
+// Returns:
+//   - An error wrapping ErrInvalidConfig if the name is empty or already registered, or New is nil.
+func (r *Registry) RegisterType(name string, nodeType NodeType) error {
+	if nodeType.New == nil {
+		return fmt.Errorf("%w: node type %q has no New function", ErrInvalidConfig, name)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if name == "" || isLeafKind(name) {
+		return fmt.Errorf("%w: %q cannot be used as a node type name", ErrInvalidConfig, name)
+	}
+	if _, ok := r.types[name]; ok {
+		return fmt.Errorf("%w: node type %q is already registered", ErrInvalidConfig, name)
+	}
+	r.types[name] = nodeType
+	return nil
+}
+
+// RegisterAction adds a named Action to the Registry.
+//
+// Parameters:
+//   - name: The name tree definitions use to refer to the action.
+//   - create: Creates the action. It is called once for every reference to the name, so the same action can
+//     be used more than once in a tree. If the action it returns has no Name, the name is used.
+//
+// Returns:
+//   - An error wrapping ErrInvalidConfig if the name is empty or already registered, or create is nil.
+func (r *Registry) RegisterAction(name string, create func() *Action) error {
+	if create == nil {
+		return fmt.Errorf("%w: leaf %q has no create function", ErrInvalidConfig, name)
+	}
+	return r.registerLeaf(name, "Action", func() Node {
+		action := create()
+		if action == nil {
+			return nil
+		}
+		if action.Name == "" {
+			action.Name = name
+		}
+		return action
+	})
+}
+
+// RegisterCondition adds a named Condition to the Registry.
+//
+// Parameters:
+//   - name: The name tree definitions use to refer to the condition.
+//   - create: Creates the condition. It is called once for every reference to the name. If the condition it
+//     returns has no Name, the name is used.
+//
+// Returns:
+//   - An error wrapping ErrInvalidConfig if the name is empty or already registered, or create is nil.
+func (r *Registry) RegisterCondition(name string, create func() *Condition) error {
+	if create == nil {
+		return fmt.Errorf("%w: leaf %q has no create function", ErrInvalidConfig, name)
+	}
+	return r.registerLeaf(name, "Condition", func() Node {
+		condition := create()
+		if condition == nil {
+			return nil
+		}
+		if condition.Name == "" {
+			condition.Name = name
+		}
+		return condition
+	})
+}
+
+// RegisterAsyncAction adds a named AsyncAction to the Registry.
+//
+// Parameters:
+//   - name: The name tree definitions use to refer to the action.
+//   - create: Creates the action. It is called once for every reference to the name. If the action it
+//     returns has no Name, the name is used.
+//
+// Returns:
+//   - An error wrapping ErrInvalidConfig if the name is empty or already registered, or create is nil.
+func (r *Registry) RegisterAsyncAction(name string, create func() *AsyncAction) error {
+	if create == nil {
+		return fmt.Errorf("%w: leaf %q has no create function", ErrInvalidConfig, name)
+	}
+	return r.registerLeaf(name, "AsyncAction", func() Node {
+		action := create()
+		if action == nil {
+			return nil
+		}
+		if action.Name == "" {
+			action.Name = name
+		}
+		return action
+	})
+}
+
+// registerLeaf adds a named leaf of the given kind to the Registry.
+func (r *Registry) registerLeaf(name, kind string, create func() Node) error {
+	if name == "" {
+		return fmt.Errorf("%w: %s name is empty", ErrInvalidConfig, kind)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.leaves[name]; ok {
+		return fmt.Errorf("%w: %q is already registered as %s", ErrInvalidConfig, name, existing.kind)
+	}
+	r.leaves[name] = leafType{kind: kind, create: create}
+	return nil
+}
+
+// Types returns the names of the node types known to the Registry.
+//
+// Returns:
+//   - The names of the built-in and registered node types, in sorted order.
+func (r *Registry) Types() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.types))
+	for name := range r.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Leaves returns the names of the leaf nodes registered with the Registry.
+//
+// Returns:
+//   - The names of the registered actions, conditions and async actions, in sorted order.
+func (r *Registry) Leaves() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.leaves))
+	for name := range r.leaves {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Build creates the node described by the Definition, together with all the nodes below it, using the built-in
+// node types and the given leaves. Use BuildWith to create custom node types as well.
+//
+// Parameters:
+//   - leaves: Functions that create the leaf nodes, keyed by the Name the definition refers to them by. If the
+//     Action, Condition or AsyncAction a function returns has no Name, the key is used.
+//
+// Returns:
+//   - The root node of the subtree.
+//   - An error naming the path of the offending node (such as "root/1/0") and wrapping ErrUnknownNode if the
+//     definition refers to an unknown node type or leaf name, or ErrInvalidConfig if a node is missing a
+//     required parameter, has an invalid parameter or has the wrong number of children.
+func (d *Definition) Build(leaves map[string]func() Node) (Node, error) {
+	r := NewRegistry()
+	for name, create := range leaves {
+		r.leaves[name] = leafType{create: namedLeaf(name, create)}
+	}
+	return r.build(d, "root")
+}
+
+// BuildWith creates the node described by the Definition, together with all the nodes below it, using the
+// node types and leaves known to a Registry.
+//
+// Parameters:
+//   - r: The Registry used to create the nodes. If nil, only the built-in node types are available.
+//
+// Returns:
+//   - The root node of the subtree.
+//   - An error naming the path of the offending node, as for Build.
+func (d *Definition) BuildWith(r *Registry) (Node, error) {
+	if r == nil {
+		r = NewRegistry()
+	}
+	return r.build(d, "root")
+}
+
+// namedLeaf wraps the function that creates a leaf so that the leaf is given the name if it has none.
+func namedLeaf(name string, create func() Node) func() Node {
+	return func() Node {
+		node := create()
+		switch leaf := node.(type) {
+		case *Action:
+			if leaf != nil && leaf.Name == "" {
+				leaf.Name = name
+			}
+		case *Condition:
+			if leaf != nil && leaf.Name == "" {
+				leaf.Name = name
+			}
+		case *AsyncAction:
+			if leaf != nil && leaf.Name == "" {
+				leaf.Name = name
+			}
+		}
+		return node
+	}
+}
+
+// build creates the node at the given path from its Definition.
+func (r *Registry) build(def *Definition, path string) (Node, error) {
+	if def == nil {
+		return nil, fmt.Errorf("%s: %w: definition is nil", path, ErrInvalidConfig)
+	}
+	if isLeafKind(def.Type) {
+		return r.buildLeaf(def, path)
+	}
+
+	r.mu.RLock()
+	nodeType, ok := r.types[def.Type]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%s: %w: no node type named %q", path, ErrUnknownNode, def.Type)
+	}
+	if len(def.Conditions) > 0 && def.Type != "Composite" {
+		return nil, fmt.Errorf("%s: %w: %s cannot have conditions", path, ErrInvalidConfig, def.Type)
+	}
+	if count := len(def.Children); count < nodeType.MinChildren || (nodeType.MaxChildren >= 0 && count > nodeType.MaxChildren) {
+		return nil, fmt.Errorf("%s: %w: %s needs %s, got %d", path, ErrInvalidConfig, def.Type, childCount(nodeType), count)
+	}
+	for _, key := range nodeType.Required {
+		if value, ok := def.Params[key]; !ok || value == nil {
+			return nil, fmt.Errorf("%s: %w: %s is missing required parameter %s", path, ErrInvalidConfig, def.Type, key)
+		}
+	}
+
+	// Build the children, numbering conditions before children the same way as Describe
+	var kids []Node
+	for i, child := range append(append([]*Definition{}, def.Conditions...), def.Children...) {
+		node, err := r.build(child, path+"/"+strconv.Itoa(i))
+		if err != nil {
+			return nil, err
+		}
+		kids = append(kids, node)
+	}
+
+	node, err := nodeType.New(def, kids)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", path, def.Type, err)
+	}
+	return node, nil
+}
+
+// buildLeaf creates the named leaf node at the given path.
+func (r *Registry) buildLeaf(def *Definition, path string) (Node, error) {
+	if len(def.Conditions) > 0 || len(def.Children) > 0 {
+		return nil, fmt.Errorf("%s: %w: %s cannot have children", path, ErrInvalidConfig, def.Type)
+	}
+	if len(def.Params) > 0 {
+		return nil, fmt.Errorf("%s: %w: %s cannot have parameters", path, ErrInvalidConfig, def.Type)
+	}
+	r.mu.RLock()
+	leaf, ok := r.leaves[def.Name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%s: %w: no %s named %q", path, ErrUnknownNode, def.Type, def.Name)
+	}
+	if leaf.kind != "" && leaf.kind != def.Type {
+		return nil, fmt.Errorf("%s: %w: %q is registered as %s, not %s", path, ErrInvalidConfig, def.Name, leaf.kind, def.Type)
+	}
+	node := leaf.create()
+	if value := reflect.ValueOf(node); node == nil || (value.Kind() == reflect.Pointer && value.IsNil()) {
+		return nil, fmt.Errorf("%s: %w: the function creating %s %q returned nil", path, ErrInvalidConfig, def.Type, def.Name)
+	}
+	return node, nil
+}
+
+// leafKind returns the kind of the named leaf ("Action", "Condition" or "AsyncAction"), and whether it is registered.
+func (r *Registry) leafKind(name string) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	leaf, ok := r.leaves[name]
+	return leaf.kind, ok
+}
+
+// hasType reports whether a node type with the given name is known to the Registry.
+func (r *Registry) hasType(name string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.types[name]
+	return ok
+}
+
+// isLeafKind reports whether a definition type refers to a named leaf.
+func isLeafKind(name string) bool {
+	return name == "Action" || name == "Condition" || name == "AsyncAction"
+}
+
+// childCount describes the number of children a node type accepts.
+func childCount(nodeType NodeType) string {
+	switch {
+	case nodeType.MaxChildren < 0:
+		return "at least " + strconv.Itoa(nodeType.MinChildren) + " children"
+	case nodeType.MinChildren == 0 && nodeType.MaxChildren == 1:
+		return "at most 1 child"
+	case nodeType.MinChildren == nodeType.MaxChildren && nodeType.MinChildren == 1:
+		return "exactly 1 child"
+	case nodeType.MinChildren == nodeType.MaxChildren:
+		return "exactly " + strconv.Itoa(nodeType.MinChildren) + " children"
+	default:
+		return "between " + strconv.Itoa(nodeType.MinChildren) + " and " + strconv.Itoa(nodeType.MaxChildren) + " children"
+	}
+}
+
+// compositeType returns the NodeType of a built-in composite node that is created from its name and children alone.
+func compositeType(create func(name string, children []Node) Node) NodeType {
+	return NodeType{
+		MaxChildren: -1,
+		New: func(def *Definition, children []Node) (Node, error) {
+			p := &params{values: def.Params}
+			node := create(def.Name, children)
+			return node, p.done()
+		},
+	}
+}
+
+// decoratorType returns the NodeType of a built-in decorator node, which has exactly one child.
+func decoratorType(create func(name string, child Node, p *params) Node, required ...string) NodeType {
+	return NodeType{
+		MinChildren: 1,
+		MaxChildren: 1,
+		Required:    required,
+		New: func(def *Definition, children []Node) (Node, error) {
+			p := &params{values: def.Params}
+			node := create(def.Name, children[0], p)
+			return node, p.done()
+		},
+	}
+}
+
+// builtinTypes holds the NodeType of every built-in node type other than the leaves.
+var builtinTypes = map[string]NodeType{
+	"Composite": {
+		MaxChildren: 1,
+		New: func(def *Definition, children []Node) (Node, error) {
+			// The conditions come first, followed by the child if there is one
+			p := &params{values: def.Params}
+			composite := &Composite{Name: def.Name, Conditions: children[:len(def.Conditions)]}
+			if len(def.Children) > 0 {
+				composite.Child = children[len(def.Conditions)]
+			}
+			return composite, p.done()
+		},
+	},
+	"Selector":         compositeType(func(name string, children []Node) Node { return &Selector{Name: name, Children: children} }),
+	"Sequence":         compositeType(func(name string, children []Node) Node { return &Sequence{Name: name, Children: children} }),
+	"MemorySelector":   compositeType(func(name string, children []Node) Node { return &MemorySelector{Name: name, Children: children} }),
+	"ReactiveSequence": compositeType(func(name string, children []Node) Node { return &ReactiveSequence{Name: name, Children: children} }),
+	"ReactiveSelector": compositeType(func(name string, children []Node) Node { return &ReactiveSelector{Name: name, Children: children} }),
+	"Parallel": {
+		MaxChildren: -1,
+		New: func(def *Definition, children []Node) (Node, error) {
+			p := &params{values: def.Params}
+			parallel := &Parallel{
+				Name:            def.Name,
+				Children:        children,
+				Policy:          p.policy("Policy"),
+				MinSuccessCount: p.int("MinSuccessCount"),
+				MinFailureCount: p.int("MinFailureCount"),
+				KeepRunning:     p.bool("KeepRunning"),
+			}
+			if err := p.done(); err != nil {
+				return nil, err
+			}
+			return parallel, parallel.Validate()
+		},
+	},
+	"ConcurrentParallel": {
+		MaxChildren: -1,
+		New: func(def *Definition, children []Node) (Node, error) {
+			p := &params{values: def.Params}
+			parallel := &ConcurrentParallel{
+				Name:            def.Name,
+				Children:        children,
+				Policy:          p.policy("Policy"),
+				MinSuccessCount: p.int("MinSuccessCount"),
+				MinFailureCount: p.int("MinFailureCount"),
+				KeepRunning:     p.bool("KeepRunning"),
+				MaxConcurrency:  p.int("MaxConcurrency"),
+			}
+			if err := p.done(); err != nil {
+				return nil, err
+			}
+			return parallel, parallel.Validate()
+		},
+	},
+	"Retry": decoratorType(func(name string, child Node, p *params) Node {
+		return &Retry{Name: name, Child: child, MaxAttempts: p.int("MaxAttempts"), Backoff: p.backoff("Backoff")}
+	}),
+	"Repeat":        decoratorType(func(name string, child Node, p *params) Node { return &Repeat{Name: name, Child: child} }),
+	"Invert":        decoratorType(func(name string, child Node, p *params) Node { return &Invert{Name: name, Child: child} }),
+	"AlwaysSuccess": decoratorType(func(name string, child Node, p *params) Node { return &AlwaysSuccess{Name: name, Child: child} }),
+	"AlwaysFailure": decoratorType(func(name string, child Node, p *params) Node { return &AlwaysFailure{Name: name, Child: child} }),
+	"RepeatN": decoratorType(func(name string, child Node, p *params) Node {
+		return &RepeatN{Name: name, Child: child, MaxCount: p.int("MaxCount")}
+	}, "MaxCount"),
+	"Forever":      decoratorType(func(name string, child Node, p *params) Node { return &Forever{Name: name, Child: child} }),
+	"WhileSuccess": decoratorType(func(name string, child Node, p *params) Node { return &WhileSuccess{Name: name, Child: child} }),
+	"WhileFailure": decoratorType(func(name string, child Node, p *params) Node { return &WhileFailure{Name: name, Child: child} }),
+	"WithTimeout": decoratorType(func(name string, child Node, p *params) Node {
+		return &WithTimeout{Name: name, Child: child, Duration: p.duration("Duration")}
+	}, "Duration"),
+	"Log": decoratorType(func(name string, child Node, p *params) Node {
+		return &Log{Name: name, Child: child, Message: p.string("Message"), LogLevel: p.level("LogLevel")}
+	}),
+}
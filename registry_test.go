@@ -0,0 +1,210 @@
+package behave
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRegistry_RegisterErrors(t *testing.T) {
+	r := NewRegistry()
+	if err := r.RegisterAction("OpenDoor", func() *Action { return &Action{} }); err != nil {
+		t.Fatalf("Registry.RegisterAction() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{name: "duplicate leaf", err: r.RegisterCondition("OpenDoor", func() *Condition { return &Condition{} })},
+		{name: "empty leaf name", err: r.RegisterAction("", func() *Action { return &Action{} })},
+		{name: "nil create", err: r.RegisterAsyncAction("Wait", nil)},
+		{name: "duplicate type", err: r.RegisterType("Sequence", NodeType{New: func(*Definition, []Node) (Node, error) { return nil, nil }})},
+		{name: "leaf kind as type", err: r.RegisterType("Action", NodeType{New: func(*Definition, []Node) (Node, error) { return nil, nil }})},
+		{name: "type without New", err: r.RegisterType("Teleport", NodeType{})},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if !errors.Is(test.err, ErrInvalidConfig) {
+				t.Errorf("registration error = %v, want %v", test.err, ErrInvalidConfig)
+			}
+		})
+	}
+}
+
+func TestRegistry_NamedLeaves(t *testing.T) {
+	r := NewRegistry()
+	opened := 0
+	r.RegisterAction("OpenDoor", func() *Action {
+		return &Action{Run: func() Status { opened++; return Success }}
+	})
+
+	def := &Definition{Type: "Sequence", Children: []*Definition{
+		{Type: "Action", Name: "OpenDoor"},
+		{Type: "Action", Name: "OpenDoor"},
+	}}
+	node, err := def.BuildWith(r)
+	if err != nil {
+		t.Fatalf("Definition.BuildWith() error = %v", err)
+	}
+	sequence := node.(*Sequence)
+	if sequence.Children[0] == sequence.Children[1] {
+		t.Errorf("Definition.BuildWith() should create a new leaf for every reference")
+	}
+	if name := sequence.Children[0].(*Action).Name; name != "OpenDoor" {
+		t.Errorf("Action.Name = %q, want %q", name, "OpenDoor")
+	}
+	if status := node.Tick(); status != Success || opened != 2 {
+		t.Errorf("Sequence.Tick() = %v with %d doors opened, want %v with 2", status, opened, Success)
+	}
+}
+
+func TestRegistry_CustomType(t *testing.T) {
+	r := NewRegistry()
+	err := r.RegisterType("FirstOf", NodeType{
+		MinChildren: 2,
+		MaxChildren: 3,
+		Required:    []string{"Label"},
+		New: func(def *Definition, children []Node) (Node, error) {
+			return &Selector{Children: children}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Registry.RegisterType() error = %v", err)
+	}
+
+	leaf := &Definition{Type: "Condition", Name: "Ready"}
+	r.RegisterCondition("Ready", func() *Condition { return &Condition{Check: func() bool { return true }} })
+
+	node, err := (&Definition{Type: "FirstOf", Params: map[string]any{"Label": "x"}, Children: []*Definition{leaf, leaf}}).BuildWith(r)
+	if err != nil {
+		t.Fatalf("Definition.BuildWith() error = %v", err)
+	}
+	if status := node.Tick(); status != Success {
+		t.Errorf("FirstOf.Tick() = %v, want %v", status, Success)
+	}
+
+	_, err = (&Definition{Type: "FirstOf", Params: map[string]any{"Label": "x"}, Children: []*Definition{leaf}}).BuildWith(r)
+	if !errors.Is(err, ErrInvalidConfig) || !strings.Contains(err.Error(), "between 2 and 3 children, got 1") {
+		t.Errorf("Definition.BuildWith() with too few children error = %v", err)
+	}
+	_, err = (&Definition{Type: "FirstOf", Children: []*Definition{leaf, leaf}}).BuildWith(r)
+	if !errors.Is(err, ErrInvalidConfig) || !strings.Contains(err.Error(), "missing required parameter Label") {
+		t.Errorf("Definition.BuildWith() without required parameter error = %v", err)
+	}
+}
+
+func TestRegistry_TypesAndLeaves(t *testing.T) {
+	r := NewRegistry()
+	r.RegisterCondition("DoorOpen", func() *Condition { return &Condition{} })
+	r.RegisterAction("OpenDoor", func() *Action { return &Action{} })
+
+	types := strings.Join(r.Types(), ",")
+	for _, expected := range []string{"Sequence", "Parallel", "WithTimeout", "Composite"} {
+		if !strings.Contains(types, expected) {
+			t.Errorf("Registry.Types() = %v, want it to contain %s", types, expected)
+		}
+	}
+	if leaves := strings.Join(r.Leaves(), ","); leaves != "DoorOpen,OpenDoor" {
+		t.Errorf("Registry.Leaves() = %v, want %v", leaves, "DoorOpen,OpenDoor")
+	}
+	if len(NewRegistry().Leaves()) != 0 {
+		t.Errorf("NewRegistry() should not share leaves between registries")
+	}
+}
+
+func TestRegistry_ConcurrentUse(t *testing.T) {
+	r := NewRegistry()
+	def := &Definition{Type: "Sequence", Children: []*Definition{{Type: "Action", Name: "Step"}}}
+	r.RegisterAction("Step", func() *Action { return &Action{} })
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			r.RegisterAction(strings.Repeat("x", i+1), func() *Action { return &Action{} })
+		}(i)
+		go func() {
+			defer wg.Done()
+			if _, err := def.BuildWith(r); err != nil {
+				t.Errorf("Definition.BuildWith() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// labeledSelector is a custom node type that describes itself, so that trees containing it can be saved.
+type labeledSelector struct {
+	Selector
+	Label string
+}
+
+func (l *labeledSelector) DescribeNode() (*Definition, error) {
+	return &Definition{Type: "Labeled", Params: map[string]any{"Label": l.Label}}, nil
+}
+
+func TestRegistry_CustomTypeRoundTrip(t *testing.T) {
+	r := NewRegistry()
+	r.RegisterType("Labeled", NodeType{
+		MaxChildren: -1,
+		Required:    []string{"Label"},
+		New: func(def *Definition, children []Node) (Node, error) {
+			label, _ := def.Params["Label"].(string)
+			return &labeledSelector{Selector: Selector{Name: def.Name, Children: children}, Label: label}, nil
+		},
+	})
+	r.RegisterCondition("Ready", func() *Condition { return &Condition{} })
+
+	data := `{"type":"Labeled","name":"pick","params":{"Label":"x"},"children":[{"type":"Condition","name":"Ready"}]}`
+	bt, err := LoadJSONWith([]byte(data), r)
+	if err != nil {
+		t.Fatalf("LoadJSONWith() error = %v", err)
+	}
+	saved, err := json.Marshal(bt)
+	if err != nil || string(saved) != data {
+		t.Errorf("json.Marshal() = %s, %v, want %s", saved, err, data)
+	}
+
+	type opaque struct{ Selector }
+	if _, err := Describe(&opaque{}); !errors.Is(err, ErrUnknownNode) {
+		t.Errorf("Describe() of a custom node that is not a Describer error = %v, want %v", err, ErrUnknownNode)
+	}
+}
+
+func TestDescribe_DropsGoValues(t *testing.T) {
+	retry := &Retry{
+		Child:       &Action{Name: "Step"},
+		MaxAttempts: 2,
+		Retryable:   func(context.Context, int) bool { return false },
+		Clock:       NewFakeClock(time.Now()),
+	}
+	def, err := Describe(retry)
+	if err != nil {
+		t.Fatalf("Describe() error = %v", err)
+	}
+	node, err := def.Build(map[string]func() Node{"Step": func() Node { return &Action{} }})
+	if err != nil {
+		t.Fatalf("Definition.Build() error = %v", err)
+	}
+	if built := node.(*Retry); built.MaxAttempts != 2 || built.Retryable != nil || built.Clock != nil {
+		t.Errorf("built Retry = %+v, want MaxAttempts kept and Retryable and Clock left unset", built)
+	}
+}
+
+func TestRegistry_NilLeaf(t *testing.T) {
+	r := NewRegistry()
+	r.RegisterAction("Broken", func() *Action { return nil })
+	def := &Definition{Type: "Sequence", Children: []*Definition{{Type: "Action", Name: "Broken"}}}
+	if _, err := def.BuildWith(r); !errors.Is(err, ErrInvalidConfig) || !strings.HasPrefix(err.Error(), "root/0:") {
+		t.Errorf("Definition.BuildWith() with a nil leaf error = %v, want %v at root/0", err, ErrInvalidConfig)
+	}
+	if _, err := def.Build(map[string]func() Node{"Broken": func() Node { return (*Action)(nil) }}); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Definition.Build() with a nil leaf error = %v, want %v", err, ErrInvalidConfig)
+	}
+}
@@ -0,0 +1,171 @@
+package behave
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Replay re-runs a BehaviorTree against a trace recorded by a Recorder, to reproduce an execution offline one tick
+// at a time. While a tick is replayed, Action, AsyncAction and Condition nodes don't run their functions: each
+// returns the result it had in the same recorded tick instead. The tree's structure and its composite and
+// decorator nodes run for real, so replaying a recorded failure against a fixed tree shows whether the fix
+// changes the outcome.
+//
+// A leaf is matched to its recorded results by its name, or by its path if it has no name, so that named leaves
+// keep their results when the structure of the tree changes. A leaf ticked more than once in a tick receives its
+// results in the order in which they were recorded. A leaf that has no recorded result left fails, and is
+// reported in ReplayStep.Missing.
+//
+// Time-based nodes see the time at which each recorded tick started, unless the tree or the node has its own
+// Clock, and the changes the recorded tick made to the Blackboard are applied once the tick has been replayed.
+type Replay struct {
+	ticks []TickRecord
+	next  int // Index of the next tick to replay
+	clock *FakeClock
+}
+
+// ReplayStep is the outcome of replaying one recorded tick.
+type ReplayStep struct {
+	Tick     int      // Number of the recorded tick
+	Recorded Status   // Status of the tree in the recorded tick
+	Status   Status   // Status of the tree in the replayed tick
+	Missing  []string // Leaves ticked in the replay that had no recorded result left, by name or path
+	Unused   []string // Leaves ticked in the recording that were not ticked in the replay, by name or path
+}
+
+// Diverged reports whether the replayed tick differs from the recorded one.
+//
+// Returns:
+//   - true if the tree ended the tick with a different Status, or if the leaves ticked differ from the recording.
+func (s ReplayStep) Diverged() bool {
+	return s.Status != s.Recorded || len(s.Missing) > 0 || len(s.Unused) > 0
+}
+
+// NewReplay creates a Replay of recorded ticks, such as those returned by ReadTrace or Recorder.Ticks.
+//
+// Parameters:
+//   - ticks: The recorded ticks, in the order in which they were recorded.
+//
+// Returns:
+//   - A pointer to a new Replay instance, positioned at the first tick.
+func NewReplay(ticks []TickRecord) *Replay {
+	return &Replay{ticks: ticks, clock: NewFakeClock(time.Time{})}
+}
+
+// Remaining returns the number of recorded ticks that have not been replayed yet.
+//
+// Returns:
+//   - The number of ticks left to replay.
+func (r *Replay) Remaining() int {
+	return len(r.ticks) - r.next
+}
+
+// Step replays the next recorded tick on the tree.
+//
+// Parameters:
+//   - bt: The tree to tick.
+//
+// Returns:
+//   - The outcome of the tick.
+//   - false if every recorded tick has already been replayed, in which case the tree is not ticked.
+func (r *Replay) Step(bt *BehaviorTree) (ReplayStep, bool) {
+	if r.next >= len(r.ticks) {
+		return ReplayStep{}, false
+	}
+	record := r.ticks[r.next]
+	r.next++
+
+	results := newReplayResults(record)
+	r.clock.Set(record.Start)
+	ctx := context.WithValue(WithClock(context.Background(), r.clock), replayKey{}, results)
+	status := bt.TickContext(ctx)
+	for _, change := range record.Blackboard {
+		if change.Deleted {
+			bt.Blackboard.Delete(change.Key)
+		} else {
+			bt.Blackboard.Set(change.Key, change.Value)
+		}
+	}
+
+	step := ReplayStep{Tick: record.Tick, Recorded: record.Status, Status: status, Missing: results.missing}
+	for key, statuses := range results.statuses {
+		if len(statuses) > 0 {
+			step.Unused = append(step.Unused, key)
+		}
+	}
+	sort.Strings(step.Unused)
+	return step, true
+}
+
+// Run replays every remaining recorded tick on the tree.
+//
+// Parameters:
+//   - bt: The tree to tick.
+//
+// Returns:
+//   - The outcome of every replayed tick, in order.
+func (r *Replay) Run(bt *BehaviorTree) []ReplayStep {
+	var steps []ReplayStep
+	for {
+		step, ok := r.Step(bt)
+		if !ok {
+			return steps
+		}
+		steps = append(steps, step)
+	}
+}
+
+// replayKey is the context key under which the results of the tick being replayed are stored.
+type replayKey struct{}
+
+// replayResults holds the recorded results of the leaves of the tick being replayed. Leaves can be ticked
+// concurrently, so it is protected by a mutex.
+type replayResults struct {
+	mu       sync.Mutex
+	statuses map[string][]Status // Results not yet returned, by leaf key, in the order in which they were recorded
+	missing  []string            // Keys of the leaves that were ticked without a result left
+}
+
+// newReplayResults collects the results of the leaves of a recorded tick from their exit events.
+func newReplayResults(record TickRecord) *replayResults {
+	results := &replayResults{statuses: map[string][]Status{}}
+	for _, event := range record.Events {
+		if event.Kind == "exit" && isLeafKind(event.Type) {
+			key := replayKeyOf(event.Name, event.Path)
+			results.statuses[key] = append(results.statuses[key], event.Status)
+		}
+	}
+	return results
+}
+
+// replayResult returns the recorded result of a leaf node if ctx carries a tick being replayed.
+//
+// Returns:
+//   - The recorded Status of the leaf, or Failure if it has no result left.
+//   - true if the tick is being replayed, false if the leaf should run normally.
+func replayResult(ctx context.Context, node Node) (Status, bool) {
+	results, ok := ctx.Value(replayKey{}).(*replayResults)
+	if !ok {
+		return Ready, false
+	}
+	key := replayKeyOf(NameOf(node), PathOf(node))
+	results.mu.Lock()
+	defer results.mu.Unlock()
+	statuses := results.statuses[key]
+	if len(statuses) == 0 {
+		results.missing = append(results.missing, key)
+		return Failure, true
+	}
+	results.statuses[key] = statuses[1:]
+	return statuses[0], true
+}
+
+// replayKeyOf returns the key a leaf's results are replayed by: its name if it has one, otherwise its path.
+func replayKeyOf(name, path string) string {
+	if name != "" {
+		return name
+	}
+	return path
+}
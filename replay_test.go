@@ -0,0 +1,117 @@
+package behave
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// recordDelivery records two ticks of a tree whose door is open in the first tick and closed in the second.
+func recordDelivery(t *testing.T) []TickRecord {
+	t.Helper()
+	open := true
+	bt := New(&ReactiveSequence{Name: "deliver", Children: []Node{
+		&Condition{Name: "door open", Check: func() bool { return open }},
+		&Action{Name: "enter", RunWithBlackboard: func(bb *Blackboard) Status {
+			steps, _ := bb.GetInt("steps")
+			bb.Set("steps", steps+1)
+			return Running
+		}},
+	}})
+	bt.Clock = NewFakeClock(time.Unix(100, 0).UTC())
+
+	var out bytes.Buffer
+	recorder := NewRecorder(RecorderOptions{Writer: &out, Blackboard: true})
+	recorder.Attach(bt)
+	bt.Tick()
+	open = false
+	bt.Clock.(*FakeClock).Advance(time.Second)
+	bt.Tick()
+
+	ticks, err := ReadTrace(&out)
+	if err != nil {
+		t.Fatalf("ReadTrace() error = %v", err)
+	}
+	return ticks
+}
+
+func TestReplay(t *testing.T) {
+	ticks := recordDelivery(t)
+	bt := New(&ReactiveSequence{Name: "deliver", Children: []Node{
+		&Condition{Name: "door open", Check: func() bool { panic("checked during a replay") }},
+		&Action{Name: "enter", Run: func() Status { panic("run during a replay") }},
+	}})
+	var starts []time.Time
+	bt.AddObserver(ObserverFuncs{Enter: func(event TickEvent) {
+		if event.Path == "root" {
+			starts = append(starts, event.Start)
+		}
+	}})
+
+	replay := NewReplay(ticks)
+	step, ok := replay.Step(bt)
+	if !ok || step.Tick != 1 || step.Status != Running || step.Diverged() {
+		t.Errorf("Replay.Step() = %+v, %v, want tick 1 Running as recorded", step, ok)
+	}
+	if steps, _ := bt.Blackboard.Get("steps"); steps != 1.0 || replay.Remaining() != 1 {
+		t.Errorf("steps = %v with %d ticks remaining, want the recorded value 1 and 1 tick", steps, replay.Remaining())
+	}
+
+	steps := replay.Run(bt)
+	if len(steps) != 1 || steps[0].Status != Failure || steps[0].Diverged() {
+		t.Errorf("Replay.Run() = %+v, want tick 2 Failure as recorded", steps)
+	}
+	if _, ok := replay.Step(bt); ok || replay.Remaining() != 0 {
+		t.Errorf("Replay.Step() after the last tick should return false")
+	}
+	want := []time.Time{time.Unix(100, 0), time.Unix(101, 0)}
+	if len(starts) != 2 || !starts[0].Equal(want[0]) || !starts[1].Equal(want[1]) {
+		t.Errorf("replayed ticks started at %v, want the recorded times %v", starts, want)
+	}
+}
+
+func TestReplay_ChangedTree(t *testing.T) {
+	ticks := recordDelivery(t)
+
+	fixed := New(&Selector{Name: "deliver", Children: []Node{
+		&Condition{Name: "door open"},
+		&Action{Name: "enter"},
+	}})
+	steps := NewReplay(ticks).Run(fixed)
+	if len(steps) != 2 {
+		t.Fatalf("Replay.Run() = %d steps, want 2", len(steps))
+	}
+	if first := steps[0]; first.Status != Success || !reflect.DeepEqual(first.Unused, []string{"enter"}) || !first.Diverged() {
+		t.Errorf("first step = %+v, want Success with enter unused", first)
+	}
+	if second := steps[1]; second.Status != Failure || !reflect.DeepEqual(second.Missing, []string{"enter"}) {
+		t.Errorf("second step = %+v, want Failure with no recorded result for enter", second)
+	}
+
+	extended := New(&Sequence{Children: []Node{
+		&Condition{Name: "door open"},
+		&Action{Name: "knock"},
+	}})
+	step, _ := NewReplay(ticks).Step(extended)
+	if step.Status != Failure || !reflect.DeepEqual(step.Missing, []string{"knock"}) || !reflect.DeepEqual(step.Unused, []string{"enter"}) {
+		t.Errorf("Replay.Step() = %+v, want Failure with knock missing and enter unused", step)
+	}
+}
+
+func TestReplay_ByPath(t *testing.T) {
+	record := TickRecord{Tick: 1, Status: Success, Events: []TraceEvent{
+		{Kind: "exit", Path: "root/0", Type: "AsyncAction", Status: Success},
+		{Kind: "exit", Path: "root/1", Type: "Condition", Status: Success},
+		{Kind: "exit", Path: "root", Type: "Sequence", Status: Success},
+	}}
+	bt := New(&Sequence{Children: []Node{
+		&AsyncAction{},
+		&Condition{},
+	}})
+
+	step, _ := NewReplay([]TickRecord{record}).Step(bt)
+	if step.Status != Success || step.Diverged() {
+		t.Errorf("Replay.Step() = %+v, want Success with the results matched by path", step)
+	}
+}
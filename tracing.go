@@ -0,0 +1,99 @@
+package behave
+
+import "context"
+
+// Attribute keys set on the spans of a traced tree.
+const (
+	AttrNodeName = "behave.node.name" // Name of the node, if it has one
+	AttrNodeType = "behave.node.type" // Type of the node, such as "Sequence"
+	AttrNodePath = "behave.node.path" // Path of the node in its tree, such as "root/0/2"
+	AttrStatus   = "behave.status"    // Status of the node or tree after the tick, such as "Success"
+)
+
+// treeSpanName is the name of the span that covers a whole tick of a BehaviorTree.
+const treeSpanName = "BehaviorTree.Tick"
+
+// failedDescription is the description of the error status of the span of a tick that returned Failure.
+const failedDescription = "tick returned Failure"
+
+// Tracer starts the spans that record the ticks of a tree. It has the shape of the Tracer of OpenTelemetry, without
+// the package depending on it; the behaveotel module adapts an OpenTelemetry tracer to it.
+//
+// A traced BehaviorTree starts a span named "BehaviorTree.Tick" for every tick, with a child span for every node
+// ticked, named after the node (or its type if it has no name). Spans are started with the context of the tick,
+// so the span of a tick is a child of any span carried by the context passed to TickContext, and the span of
+// each node is carried by the context passed to the node. Since ConcurrentParallel ticks its children on
+// separate goroutines, a Tracer must be safe for concurrent use.
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span) // Start a span that is a child of any span carried by ctx
+}
+
+// Span is a span started by a Tracer. The span of a node or tree whose tick returns Failure is marked as an
+// error with SetError.
+type Span interface {
+	SetAttribute(key, value string) // Set an attribute of the span, such as AttrStatus
+	SetError(description string)    // Set the status of the span to an error with the given description
+	End()                           // Complete the span
+}
+
+// tracerKey is the context key under which a Tracer is stored.
+type tracerKey struct{}
+
+// WithTracer returns a copy of ctx that carries the given Tracer. BehaviorTree.TickContext uses it to trace the
+// ticks of every node in the tree.
+//
+// Parameters:
+//   - ctx: The parent context.
+//   - tracer: The Tracer to add to the context.
+//
+// Returns:
+//   - A new context carrying the Tracer.
+func WithTracer(ctx context.Context, tracer Tracer) context.Context {
+	return context.WithValue(ctx, tracerKey{}, tracer)
+}
+
+// TracerFromContext returns the Tracer carried by ctx.
+//
+// Parameters:
+//   - ctx: The context to read from.
+//
+// Returns:
+//   - The Tracer carried by the context, or nil if there is none.
+func TracerFromContext(ctx context.Context) Tracer {
+	tracer, _ := ctx.Value(tracerKey{}).(Tracer)
+	return tracer
+}
+
+// traceTick ticks the node with the context of a new span, if ctx carries a Tracer.
+func traceTick(ctx context.Context, node Node, tick func(ctx context.Context) Status) Status {
+	tracer := TracerFromContext(ctx)
+	if tracer == nil {
+		return tick(ctx)
+	}
+
+	name, kind := NameOf(node), typeName(node)
+	spanName := kind
+	if name != "" {
+		spanName = name
+	}
+	ctx, span := tracer.Start(ctx, spanName)
+	defer span.End()
+	if name != "" {
+		span.SetAttribute(AttrNodeName, name)
+	}
+	span.SetAttribute(AttrNodeType, kind)
+	if path := PathOf(node); path != "" {
+		span.SetAttribute(AttrNodePath, path)
+	}
+	status := tick(ctx)
+	endSpan(span, status)
+	return status
+}
+
+// endSpan records the status of a tick on its span, marking the span as an error if the tick failed.
+func endSpan(span Span, status Status) {
+	span.SetAttribute(AttrStatus, status.String())
+	if status == Failure {
+		span.SetError(failedDescription)
+	}
+}
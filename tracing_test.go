@@ -0,0 +1,105 @@
+package behave
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// memorySpan is a span recorded by a memoryTracer.
+type memorySpan struct {
+	name   string
+	parent *memorySpan
+	attrs  map[string]string
+	err    string
+	ended  bool
+}
+
+func (s *memorySpan) SetAttribute(key, value string) { s.attrs[key] = value }
+func (s *memorySpan) SetError(description string)    { s.err = description }
+func (s *memorySpan) End()                           { s.ended = true }
+
+// memorySpanKey is the context key under which a memoryTracer stores the current span.
+type memorySpanKey struct{}
+
+// memoryTracer is a Tracer that exports its spans to memory, in the order in which they were started.
+type memoryTracer struct {
+	mu    sync.Mutex
+	spans []*memorySpan
+}
+
+func (t *memoryTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	parent, _ := ctx.Value(memorySpanKey{}).(*memorySpan)
+	span := &memorySpan{name: name, parent: parent, attrs: map[string]string{}}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.spans = append(t.spans, span)
+	return context.WithValue(ctx, memorySpanKey{}, span), span
+}
+
+func TestBehaviorTree_Tracer(t *testing.T) {
+	tracer := &memoryTracer{}
+	bt := New(&Sequence{Name: "patrol", Children: []Node{
+		&Condition{Check: func() bool { return true }},
+		&Invert{Child: &Action{Name: "walk", Run: func() Status { return Success }}},
+	}})
+	bt.Tracer = tracer
+
+	request := &memorySpan{name: "GET /patrol", attrs: map[string]string{}}
+	ctx := context.WithValue(context.Background(), memorySpanKey{}, request)
+	if status := bt.TickContext(ctx); status != Failure {
+		t.Fatalf("BehaviorTree.TickContext() = %v, want %v", status, Failure)
+	}
+
+	want := []struct {
+		name, parent string
+		attrs        map[string]string
+	}{
+		{"BehaviorTree.Tick", "GET /patrol", map[string]string{AttrStatus: "Failure"}},
+		{"patrol", "BehaviorTree.Tick", map[string]string{AttrNodeName: "patrol", AttrNodeType: "Sequence", AttrNodePath: "root", AttrStatus: "Failure"}},
+		{"Condition", "patrol", map[string]string{AttrNodeType: "Condition", AttrNodePath: "root/0", AttrStatus: "Success"}},
+		{"Invert", "patrol", map[string]string{AttrNodeType: "Invert", AttrNodePath: "root/1", AttrStatus: "Failure"}},
+		{"walk", "Invert", map[string]string{AttrNodeName: "walk", AttrNodeType: "Action", AttrNodePath: "root/1/0", AttrStatus: "Success"}},
+	}
+	if len(tracer.spans) != len(want) {
+		t.Fatalf("exported %d spans, want %d", len(tracer.spans), len(want))
+	}
+	for i, span := range tracer.spans {
+		if span.name != want[i].name || span.parent == nil || span.parent.name != want[i].parent {
+			t.Errorf("span %d = %q, want %q as a child of %q", i, span.name, want[i].name, want[i].parent)
+		}
+		if !reflect.DeepEqual(span.attrs, want[i].attrs) || !span.ended {
+			t.Errorf("span %q has attributes %v, want %v and ended", span.name, span.attrs, want[i].attrs)
+		}
+		if failed := want[i].attrs[AttrStatus] == "Failure"; failed != (span.err != "") {
+			t.Errorf("span %q has error %q, want an error only if the tick returned Failure", span.name, span.err)
+		}
+	}
+}
+
+func TestWithTracer(t *testing.T) {
+	tracer := &memoryTracer{}
+	bt := New(&ConcurrentParallel{Policy: ParallelAll, Children: []Node{
+		&Action{Run: func() Status { return Success }},
+		&Action{Run: func() Status { return Success }},
+	}})
+	bt.TickContext(WithTracer(context.Background(), tracer))
+
+	if len(tracer.spans) != 4 {
+		t.Fatalf("exported %d spans, want the tree, the parallel node and its 2 children", len(tracer.spans))
+	}
+	for _, span := range tracer.spans[2:] {
+		if span.parent != tracer.spans[1] {
+			t.Errorf("span %q of a concurrent child is not a child of the span of its parent", span.attrs[AttrNodePath])
+		}
+	}
+
+	if TracerFromContext(context.Background()) != nil {
+		t.Errorf("TracerFromContext() of a context without a Tracer should return nil")
+	}
+	New(&Action{Run: func() Status { return Success }}).Tick()
+	if len(tracer.spans) != 4 {
+		t.Errorf("a tree ticked without a Tracer exported spans")
+	}
+}
@@ -0,0 +1,140 @@
+package behave
+
+import (
+	"errors"
+	"strconv"
+)
+
+// Parent is implemented by nodes that have children. Every built-in composite and decorator node implements
+// Parent, so tooling can enumerate the nodes of a tree without knowing the fields in which each node type keeps
+// its children. Custom composite and decorator nodes should implement it too, so that they take part in
+// Walk, in the binding of the tree's Blackboard and in the assignment of paths.
+type Parent interface {
+	ChildNodes() []Node // Returns the children of the node in the order in which they are ticked
+}
+
+// ChildrenOf returns the children of a node.
+//
+// Parameters:
+//   - node: The node whose children are returned.
+//
+// Returns:
+//   - The children of the node in the order in which they are ticked, without the ones that are nil. The
+//     conditions of a Composite node come before its child. Nodes that don't implement Parent have no children.
+//     Since nil children are dropped, the index of a child in the result is not always the last element of its
+//     path; Walk numbers the children by their position in ChildNodes instead.
+func ChildrenOf(node Node) []Node {
+	parent, ok := node.(Parent)
+	if !ok {
+		return nil
+	}
+	nodes := parent.ChildNodes()
+	result := make([]Node, 0, len(nodes))
+	for _, child := range nodes {
+		if child != nil {
+			result = append(result, child)
+		}
+	}
+	return result
+}
+
+// childNodes returns the children of a node including the nil ones, so that the index of each child is the last
+// element of its path.
+func childNodes(node Node) []Node {
+	if parent, ok := node.(Parent); ok {
+		return parent.ChildNodes()
+	}
+	return nil
+}
+
+// childSlice returns the child of a decorator node as a slice, which is empty if the child is not set.
+func childSlice(child Node) []Node {
+	if child == nil {
+		return []Node{}
+	}
+	return []Node{child}
+}
+
+// WalkOrder determines whether Walk visits a node before or after the nodes below it.
+type WalkOrder int
+
+const (
+	// PreOrder visits every node before its children.
+	PreOrder WalkOrder = iota
+	// PostOrder visits every node after its children.
+	PostOrder
+)
+
+// String returns the string representation of the WalkOrder.
+func (o WalkOrder) String() string {
+	switch o {
+	case PreOrder:
+		return "PreOrder"
+	case PostOrder:
+		return "PostOrder"
+	default:
+		return "Unknown"
+	}
+}
+
+// SkipChildren can be returned by a Visitor during a PreOrder walk to skip the children of the node it was
+// called for. Walk doesn't return it as an error, and ignores it during a PostOrder walk.
+var SkipChildren = errors.New("behave: skip children")
+
+// Visitor is called by Walk for every node of the tree.
+//
+// Parameters:
+//   - node: The node being visited.
+//   - depth: The depth of the node, which is zero for the root of the walk.
+//   - path: The path of the node, such as "root/0/2", numbered from the root of the walk the same way as
+//     AssignPaths.
+//
+// Returns:
+//   - nil to continue the walk, SkipChildren to skip the children of the node, or any other error to stop
+//     the walk, which Walk then returns.
+type Visitor func(node Node, depth int, path string) error
+
+// Walk visits the node and every node below it, in depth-first order, children in the order in which they are
+// ticked (see ChildrenOf). Each child is numbered by its position in the ChildNodes of its parent, so a nil child
+// is skipped but still takes up its index, and the paths match the order in which a composite ticks its children.
+//
+// Parameters:
+//   - root: The root of the subtree to walk. If nil, nothing is visited.
+//   - order: Whether each node is visited before (PreOrder) or after (PostOrder) its children.
+//   - visit: The function called for every node.
+//
+// Returns:
+//   - The error returned by visit that stopped the walk, or nil if every node was visited.
+func Walk(root Node, order WalkOrder, visit Visitor) error {
+	if root == nil {
+		return nil
+	}
+	return walk(root, order, visit, 0, "root")
+}
+
+// walk visits the node at the given depth and path and the nodes below it, in the given order.
+func walk(node Node, order WalkOrder, visit Visitor, depth int, path string) error {
+	if order == PreOrder {
+		if err := visit(node, depth, path); err != nil {
+			if err == SkipChildren {
+				return nil
+			}
+			return err
+		}
+	}
+	for i, child := range childNodes(node) {
+		if child == nil {
+			continue
+		}
+		if err := walk(child, order, visit, depth+1, path+"/"+strconv.Itoa(i)); err != nil {
+			return err
+		}
+	}
+	if order == PostOrder {
+		// The children have already been visited, so there is nothing left to skip
+		if err := visit(node, depth, path); err != SkipChildren {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package behave
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// testParent is a custom decorator node that only exposes its child through the Parent interface.
+type testParent struct {
+	child Node
+}
+
+func (p *testParent) Tick() Status       { return p.child.Tick() }
+func (p *testParent) Reset() Status      { return p.child.Reset() }
+func (p *testParent) Status() Status     { return p.child.Status() }
+func (p *testParent) String() string     { return "testParent" }
+func (p *testParent) ChildNodes() []Node { return []Node{p.child} }
+
+// walkTestTree returns a tree that mixes composites, decorators, conditions and a custom Parent node.
+func walkTestTree() Node {
+	return &Sequence{Name: "seq", Children: []Node{
+		&Composite{Name: "guard", Conditions: []Node{&Condition{Name: "check"}}, Child: &Action{Name: "act"}},
+		&testParent{child: &Invert{Name: "invert", Child: &Action{Name: "leaf"}}},
+	}}
+}
+
+// walkLabel identifies a node visited by Walk in the tests.
+func walkLabel(node Node, depth int, path string) string {
+	name := NameOf(node)
+	if name == "" {
+		name = reflect.TypeOf(node).Elem().Name()
+	}
+	return fmt.Sprintf("%d %s %s", depth, path, name)
+}
+
+func TestChildrenOf(t *testing.T) {
+	check, act := &Condition{}, &Action{}
+	tests := []struct {
+		name string
+		node Node
+		want []Node
+	}{
+		{name: "composite", node: &Composite{Conditions: []Node{check}, Child: act}, want: []Node{check, act}},
+		{name: "missing child", node: &Retry{}, want: []Node{}},
+		{name: "nil children", node: &Selector{Children: []Node{nil, act, nil}}, want: []Node{act}},
+		{name: "leaf", node: act, want: nil},
+		{name: "custom parent", node: &testParent{child: check}, want: []Node{check}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := ChildrenOf(test.node); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("ChildrenOf() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestWalk(t *testing.T) {
+	tests := []struct {
+		order WalkOrder
+		want  []string
+	}{
+		{order: PreOrder, want: []string{
+			"0 root seq", "1 root/0 guard", "2 root/0/0 check", "2 root/0/1 act",
+			"1 root/1 testParent", "2 root/1/0 invert", "3 root/1/0/0 leaf",
+		}},
+		{order: PostOrder, want: []string{
+			"2 root/0/0 check", "2 root/0/1 act", "1 root/0 guard",
+			"3 root/1/0/0 leaf", "2 root/1/0 invert", "1 root/1 testParent", "0 root seq",
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.order.String(), func(t *testing.T) {
+			var got []string
+			err := Walk(walkTestTree(), test.order, func(node Node, depth int, path string) error {
+				got = append(got, walkLabel(node, depth, path))
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("Walk() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Walk() visited\n%q\nwant\n%q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestWalk_NilChildren(t *testing.T) {
+	act := &Action{Name: "act"}
+	var visited []string
+	_ = Walk(&Sequence{Children: []Node{nil, act, &Retry{}}}, PreOrder, func(node Node, depth int, path string) error {
+		visited = append(visited, path)
+		return nil
+	})
+	if want := []string{"root", "root/1", "root/2"}; !reflect.DeepEqual(visited, want) {
+		t.Errorf("Walk() visited %q, want %q with the nil child keeping its index", visited, want)
+	}
+
+	bt := New(&Selector{Children: []Node{nil, act}})
+	AssignPaths(bt.Root)
+	if path := PathOf(act); path != "root/1" {
+		t.Errorf("PathOf(child after a nil child) = %q, want %q", path, "root/1")
+	}
+	if found := bt.Find(ByPath("root/1")); found != act {
+		t.Errorf("BehaviorTree.Find(ByPath(root/1)) = %v, want the child after the nil child", found)
+	}
+	if nodes := (&Invert{}).ChildNodes(); nodes == nil || len(nodes) != 0 {
+		t.Errorf("Invert.ChildNodes() without a child = %#v, want an empty slice", nodes)
+	}
+	if nodes := (&Composite{Conditions: []Node{act}}).ChildNodes(); len(nodes) != 1 {
+		t.Errorf("Composite.ChildNodes() without a child = %v, want only the conditions", nodes)
+	}
+}
+
+func TestWalk_SkipAndStop(t *testing.T) {
+	var visited []string
+	err := Walk(walkTestTree(), PreOrder, func(node Node, depth int, path string) error {
+		visited = append(visited, path)
+		if _, ok := node.(*Composite); ok {
+			return SkipChildren
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Walk() with SkipChildren error = %v", err)
+	}
+	if want := []string{"root", "root/0", "root/1", "root/1/0", "root/1/0/0"}; !reflect.DeepEqual(visited, want) {
+		t.Errorf("Walk() with SkipChildren visited %q, want %q", visited, want)
+	}
+
+	stop := errors.New("found")
+	count := 0
+	err = Walk(walkTestTree(), PostOrder, func(node Node, depth int, path string) error {
+		count++
+		if NameOf(node) == "act" {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) || count != 2 {
+		t.Errorf("Walk() stopped with %v after %d nodes, want %v after 2", err, count, stop)
+	}
+
+	if err := Walk(nil, PreOrder, func(Node, int, string) error { return stop }); err != nil {
+		t.Errorf("Walk(nil) error = %v, want nil", err)
+	}
+}
+
+func TestBehaviorTree_CustomParent(t *testing.T) {
+	var seen *Blackboard
+	leaf := &Action{RunWithBlackboard: func(bb *Blackboard) Status {
+		seen = bb
+		return Success
+	}}
+	bt := New(&testParent{child: leaf})
+	bt.Tick()
+	if seen != bt.Blackboard {
+		t.Errorf("a child of a custom Parent node should be bound to the tree's Blackboard")
+	}
+	if path := PathOf(leaf); path != "root/0" {
+		t.Errorf("PathOf(child of custom Parent) = %q, want %q", path, "root/0")
+	}
+}
@@ -0,0 +1,449 @@
+package behave
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// xmlMainTree is the ID of the tree written by BehaviorTree.MarshalXML.
+const xmlMainTree = "MainTree"
+
+// xmlElement is an element of a BehaviorTree.CPP XML document. It is kept generic so that custom node types
+// and the shorthand form of leaves (<OpenDoor/> for <Action ID="OpenDoor"/>) can be read.
+type xmlElement struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr   `xml:",any,attr"`
+	Children []xmlElement `xml:",any"`
+}
+
+// attr returns the value of the named attribute, and whether it is set.
+func (e *xmlElement) attr(name string) (string, bool) {
+	for _, attr := range e.Attrs {
+		if attr.Name.Local == name {
+			return attr.Value, true
+		}
+	}
+	return "", false
+}
+
+// intAttr returns the value of the named integer attribute. If the attribute is not set, it returns def.
+func (e *xmlElement) intAttr(name string, def int, path string) (int, error) {
+	value, ok := e.attr(name)
+	if !ok {
+		return def, nil
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w: attribute %s of %s must be an integer, got %q", path, ErrInvalidConfig, name, e.XMLName.Local, value)
+	}
+	return i, nil
+}
+
+// LoadXML creates a BehaviorTree from a BehaviorTree.CPP (Groot) XML document. The tree named by the
+// main_tree_to_execute attribute of <root> is loaded, or the only tree if the document has just one.
+// <SubTree ID="..."/> elements are replaced by the tree they refer to.
+//
+// The BehaviorTree.CPP nodes are mapped to the nodes of this package: Sequence, Fallback (Selector), Parallel,
+// ReactiveSequence, ReactiveFallback (ReactiveSelector), RetryUntilSuccessful (Retry), Inverter (Invert),
+// Timeout (WithTimeout), Repeat (Repeat or RepeatN), ForceSuccess (AlwaysSuccess), ForceFailure (AlwaysFailure)
+// and KeepRunningUntilFailure (WhileSuccess). Leaves are looked up in the Registry, either written as
+// <Action ID="OpenDoor"/> and <Condition ID="DoorOpen"/> or in the shorthand form <OpenDoor/>. Any other
+// element is built as the registered node type of the same name, with its attributes as parameters. The name
+// attribute of a node that isn't a leaf becomes its Name.
+//
+// Parameters:
+//   - data: The XML document.
+//   - r: The Registry used to create the nodes. If nil, only the built-in node types are available.
+//
+// Returns:
+//   - A pointer to a new BehaviorTree instance.
+//   - An error if the document is not valid XML or the tree cannot be built (see Definition.Build).
+func LoadXML(data []byte, r *Registry) (*BehaviorTree, error) {
+	if r == nil {
+		r = NewRegistry()
+	}
+	var doc xmlElement
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if doc.XMLName.Local != "root" {
+		return nil, fmt.Errorf("%w: XML document must start with <root>, got <%s>", ErrInvalidConfig, doc.XMLName.Local)
+	}
+
+	// Collect the trees of the document, ignoring other elements such as <TreeNodesModel>
+	trees := map[string]*xmlElement{}
+	var ids []string
+	for i := range doc.Children {
+		if tree := &doc.Children[i]; tree.XMLName.Local == "BehaviorTree" {
+			id, _ := tree.attr("ID")
+			trees[id] = tree
+			ids = append(ids, id)
+		}
+	}
+	main, ok := doc.attr("main_tree_to_execute")
+	if !ok {
+		if len(ids) != 1 {
+			return nil, fmt.Errorf("%w: main_tree_to_execute must be set when the document has %d trees", ErrInvalidConfig, len(ids))
+		}
+		main = ids[0]
+	}
+
+	def, err := xmlTree(main, trees, r, "root", nil)
+	if err != nil {
+		return nil, err
+	}
+	root, err := def.BuildWith(r)
+	if err != nil {
+		return nil, err
+	}
+	return New(root), nil
+}
+
+// xmlTree returns the Definition of the tree with the given ID. The IDs of the trees that include it are passed
+// in including, so that a tree that includes itself is reported rather than expanded forever.
+func xmlTree(id string, trees map[string]*xmlElement, r *Registry, path string, including []string) (*Definition, error) {
+	tree, ok := trees[id]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w: no BehaviorTree with ID %q", path, ErrUnknownNode, id)
+	}
+	for _, parent := range including {
+		if parent == id {
+			return nil, fmt.Errorf("%s: %w: BehaviorTree %q includes itself", path, ErrInvalidConfig, id)
+		}
+	}
+	if len(tree.Children) != 1 {
+		return nil, fmt.Errorf("%s: %w: BehaviorTree %q needs exactly 1 root node, got %d", path, ErrInvalidConfig, id, len(tree.Children))
+	}
+	return xmlNode(&tree.Children[0], trees, r, path, append(including, id))
+}
+
+// xmlNode returns the Definition of the node written as the given element.
+func xmlNode(e *xmlElement, trees map[string]*xmlElement, r *Registry, path string, including []string) (*Definition, error) {
+	name := e.XMLName.Local
+	switch name {
+	case "SubTree":
+		id, ok := e.attr("ID")
+		if !ok {
+			return nil, fmt.Errorf("%s: %w: SubTree has no ID", path, ErrInvalidConfig)
+		}
+		return xmlTree(id, trees, r, path, including)
+	case "Action", "Condition":
+		id, ok := e.attr("ID")
+		if !ok {
+			return nil, fmt.Errorf("%s: %w: %s has no ID", path, ErrInvalidConfig, name)
+		}
+		return xmlLeaf(e, name, id, r, path)
+	}
+	if _, ok := r.leafKind(name); ok {
+		return xmlLeaf(e, name, name, r, path)
+	}
+
+	// The BehaviorTree.CPP attributes are converted to parameters; any other attribute is used as is
+	def := &Definition{Type: name, Params: map[string]any{}}
+	def.Name, _ = e.attr("name")
+	used := map[string]bool{"name": true}
+	var err error
+	switch name {
+	case "Fallback":
+		def.Type = "Selector"
+	case "ReactiveFallback":
+		def.Type = "ReactiveSelector"
+	case "Inverter":
+		def.Type = "Invert"
+	case "ForceSuccess":
+		def.Type = "AlwaysSuccess"
+	case "ForceFailure":
+		def.Type = "AlwaysFailure"
+	case "KeepRunningUntilFailure":
+		def.Type = "WhileSuccess"
+	case "RetryUntilSuccessful":
+		def.Type = "Retry"
+		attempts, err := e.intAttr("num_attempts", -1, path)
+		if err != nil {
+			return nil, err
+		}
+		if attempts > 0 {
+			def.Params["MaxAttempts"] = attempts
+		}
+		used["num_attempts"] = true
+	case "Timeout":
+		def.Type = "WithTimeout"
+		if _, ok := e.attr("msec"); !ok {
+			return nil, fmt.Errorf("%s: %w: Timeout is missing attribute msec", path, ErrInvalidConfig)
+		}
+		msec, err := e.intAttr("msec", 0, path)
+		if err != nil {
+			return nil, err
+		}
+		def.Params["Duration"] = (time.Duration(msec) * time.Millisecond).String()
+		used["msec"] = true
+	case "Repeat":
+		if _, ok := e.attr("num_cycles"); !ok {
+			break // The Repeat node of this package
+		}
+		cycles, err := e.intAttr("num_cycles", 0, path)
+		if err != nil {
+			return nil, err
+		}
+		if cycles >= 0 {
+			def.Type = "RepeatN"
+			def.Params["MaxCount"] = cycles
+		}
+		used["num_cycles"] = true
+	case "Parallel":
+		if err = xmlParallel(e, def, len(e.Children), used, path); err != nil {
+			return nil, err
+		}
+	default:
+		if !r.hasType(name) {
+			return nil, fmt.Errorf("%s: %w: no node type or leaf named %q", path, ErrUnknownNode, name)
+		}
+	}
+	for _, attr := range e.Attrs {
+		if !used[attr.Name.Local] {
+			def.Params[attr.Name.Local] = attr.Value
+		}
+	}
+	if len(def.Params) == 0 {
+		def.Params = nil
+	}
+
+	// The conditions of a Composite are wrapped in a <Conditions> element and numbered before its child
+	var conditions, children []*xmlElement
+	for i := range e.Children {
+		child := &e.Children[i]
+		if def.Type == "Composite" && child.XMLName.Local == "Conditions" {
+			for j := range child.Children {
+				conditions = append(conditions, &child.Children[j])
+			}
+		} else {
+			children = append(children, child)
+		}
+	}
+	for i, child := range append(conditions, children...) {
+		childDef, err := xmlNode(child, trees, r, path+"/"+strconv.Itoa(i), including)
+		if err != nil {
+			return nil, err
+		}
+		if i < len(conditions) {
+			def.Conditions = append(def.Conditions, childDef)
+		} else {
+			def.Children = append(def.Children, childDef)
+		}
+	}
+	return def, nil
+}
+
+// xmlLeaf returns the Definition of a leaf referring to the registered leaf with the given ID.
+func xmlLeaf(e *xmlElement, kind, id string, r *Registry, path string) (*Definition, error) {
+	if len(e.Children) > 0 {
+		return nil, fmt.Errorf("%s: %w: %s cannot have children", path, ErrInvalidConfig, id)
+	}
+	for _, attr := range e.Attrs {
+		if attr.Name.Local != "ID" && attr.Name.Local != "name" {
+			return nil, fmt.Errorf("%s: %w: %s has unsupported port %s", path, ErrInvalidConfig, id, attr.Name.Local)
+		}
+	}
+	// An AsyncAction is written as an Action, and a shorthand leaf doesn't name its kind
+	if registered, ok := r.leafKind(id); ok && (kind == id || (kind == "Action" && registered == "AsyncAction")) {
+		kind = registered
+	}
+	return &Definition{Type: kind, Name: id}, nil
+}
+
+// xmlParallel converts the success_count and failure_count attributes of a BehaviorTree.CPP Parallel node,
+// where -1 stands for all children. Their defaults require every child to succeed and fail on the first
+// failure. If the element uses the parameters of this package instead, they are used as is.
+func xmlParallel(e *xmlElement, def *Definition, childCount int, used map[string]bool, path string) error {
+	for _, native := range []string{"Policy", "MinSuccessCount", "MinFailureCount"} {
+		if _, ok := e.attr(native); ok {
+			return nil
+		}
+	}
+	successCount, err := e.intAttr("success_threshold", -1, path) // BehaviorTree.CPP 3
+	if err != nil {
+		return err
+	}
+	if successCount, err = e.intAttr("success_count", successCount, path); err != nil {
+		return err
+	}
+	failureCount, err := e.intAttr("failure_threshold", 1, path) // BehaviorTree.CPP 3
+	if err != nil {
+		return err
+	}
+	if failureCount, err = e.intAttr("failure_count", failureCount, path); err != nil {
+		return err
+	}
+	if successCount < 0 {
+		successCount = childCount
+	}
+	if failureCount < 0 {
+		failureCount = childCount
+	}
+	def.Params["MinSuccessCount"] = successCount
+	def.Params["MinFailureCount"] = failureCount
+	for _, attr := range []string{"success_count", "success_threshold", "failure_count", "failure_threshold"} {
+		used[attr] = true
+	}
+	return nil
+}
+
+// MarshalXML encodes the BehaviorTree as a BehaviorTree.CPP (Groot) XML document with a single tree named
+// MainTree, so that xml.Marshal(bt) writes a document LoadXML can read. Nodes are written as their
+// BehaviorTree.CPP equivalent where there is one (see LoadXML); other nodes are written under their own type
+// name, with their parameters as attributes. The start element is ignored, as the document always starts
+// with <root>.
+//
+// Parameters:
+//   - e: The encoder to write the document to.
+//   - start: The start element suggested by the encoder. It is not used.
+//
+// Returns:
+//   - An error if the tree cannot be described (see Describe), if it has a parameter that cannot be written
+//     as an attribute, such as the Backoff of a Retry, or if the encoder fails.
+func (bt *BehaviorTree) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	def, err := Describe(bt.Root)
+	if err != nil {
+		return err
+	}
+	root, err := definitionXML(def, "root")
+	if err != nil {
+		return err
+	}
+	doc := xmlElement{
+		XMLName: xml.Name{Local: "root"},
+		Attrs: []xml.Attr{
+			{Name: xml.Name{Local: "BTCPP_format"}, Value: "4"},
+			{Name: xml.Name{Local: "main_tree_to_execute"}, Value: xmlMainTree},
+		},
+		Children: []xmlElement{{
+			XMLName:  xml.Name{Local: "BehaviorTree"},
+			Attrs:    []xml.Attr{{Name: xml.Name{Local: "ID"}, Value: xmlMainTree}},
+			Children: []xmlElement{root},
+		}},
+	}
+	return e.Encode(doc)
+}
+
+// definitionXML returns the element that writes the node described by def.
+func definitionXML(def *Definition, path string) (xmlElement, error) {
+	e := xmlElement{XMLName: xml.Name{Local: def.Type}}
+	setAttr := func(name, value string) {
+		e.Attrs = append(e.Attrs, xml.Attr{Name: xml.Name{Local: name}, Value: value})
+	}
+
+	if !isLeafKind(def.Type) && def.Name != "" {
+		setAttr("name", def.Name)
+	}
+
+	p := &params{values: def.Params}
+	generic := false
+	switch def.Type {
+	case "Action", "AsyncAction":
+		e.XMLName.Local = "Action"
+		setAttr("ID", def.Name)
+	case "Condition":
+		setAttr("ID", def.Name)
+	case "Selector":
+		e.XMLName.Local = "Fallback"
+	case "ReactiveSelector":
+		e.XMLName.Local = "ReactiveFallback"
+	case "Invert":
+		e.XMLName.Local = "Inverter"
+	case "AlwaysSuccess":
+		e.XMLName.Local = "ForceSuccess"
+	case "AlwaysFailure":
+		e.XMLName.Local = "ForceFailure"
+	case "WhileSuccess":
+		e.XMLName.Local = "KeepRunningUntilFailure"
+	case "Repeat":
+		setAttr("num_cycles", "-1")
+	case "RepeatN":
+		e.XMLName.Local = "Repeat"
+		setAttr("num_cycles", strconv.Itoa(p.int("MaxCount")))
+	case "Retry":
+		if _, ok := def.Params["Backoff"]; ok {
+			return e, fmt.Errorf("%s: %w: the Backoff of a Retry cannot be written as XML", path, ErrInvalidConfig)
+		}
+		e.XMLName.Local = "RetryUntilSuccessful"
+		attempts := p.int("MaxAttempts")
+		if attempts <= 0 {
+			attempts = -1
+		}
+		setAttr("num_attempts", strconv.Itoa(attempts))
+	case "WithTimeout":
+		e.XMLName.Local = "Timeout"
+		setAttr("msec", strconv.FormatInt(p.duration("Duration").Milliseconds(), 10))
+	case "Parallel":
+		// Race has no BehaviorTree.CPP equivalent, so it keeps the parameters of this package
+		policy := p.policy("Policy")
+		if policy == ParallelRace || p.bool("KeepRunning") {
+			generic = true
+			break
+		}
+		childCount := len(def.Children)
+		successCount, failureCount := p.int("MinSuccessCount"), p.int("MinFailureCount")
+		switch policy {
+		case ParallelAll:
+			successCount, failureCount = -1, 1
+		case ParallelAny:
+			successCount, failureCount = 1, -1
+		default:
+			if successCount <= 0 {
+				successCount = 1
+			}
+			if failureCount <= 0 {
+				failureCount = childCount - successCount + 1
+			}
+		}
+		setAttr("success_count", strconv.Itoa(successCount))
+		setAttr("failure_count", strconv.Itoa(failureCount))
+	default:
+		generic = true
+	}
+	if p.err != nil {
+		return e, fmt.Errorf("%s: %w", path, p.err)
+	}
+	if generic {
+		keys := make([]string, 0, len(def.Params))
+		for key := range def.Params {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			switch value := def.Params[key].(type) {
+			case map[string]any, []any:
+				return e, fmt.Errorf("%s: %w: parameter %s of %s cannot be written as XML", path, ErrInvalidConfig, key, def.Type)
+			default:
+				setAttr(key, fmt.Sprint(value))
+			}
+		}
+	}
+
+	// The conditions of a Composite are wrapped in a <Conditions> element
+	index := 0
+	if len(def.Conditions) > 0 {
+		conditions := xmlElement{XMLName: xml.Name{Local: "Conditions"}}
+		for _, condition := range def.Conditions {
+			child, err := definitionXML(condition, path+"/"+strconv.Itoa(index))
+			if err != nil {
+				return e, err
+			}
+			conditions.Children = append(conditions.Children, child)
+			index++
+		}
+		e.Children = append(e.Children, conditions)
+	}
+	for _, childDef := range def.Children {
+		child, err := definitionXML(childDef, path+"/"+strconv.Itoa(index))
+		if err != nil {
+			return e, err
+		}
+		e.Children = append(e.Children, child)
+		index++
+	}
+	return e, nil
+}
@@ -0,0 +1,142 @@
+package behave
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadXML(t *testing.T) {
+	data := `<?xml version="1.0"?>
+<root BTCPP_format="4" main_tree_to_execute="MainTree">
+  <BehaviorTree ID="MainTree">
+    <Sequence name="root_sequence">
+      <Fallback>
+        <Condition ID="DoorOpen"/>
+        <RetryUntilSuccessful num_attempts="3">
+          <Action ID="Succeed"/>
+        </RetryUntilSuccessful>
+      </Fallback>
+      <SubTree ID="PassThrough"/>
+      <Parallel success_count="1" failure_count="-1">
+        <Inverter><Fail/></Inverter>
+        <Repeat num_cycles="2"><Succeed/></Repeat>
+      </Parallel>
+    </Sequence>
+  </BehaviorTree>
+  <BehaviorTree ID="PassThrough">
+    <Timeout msec="1500">
+      <ForceSuccess><Action ID="Keep"/></ForceSuccess>
+    </Timeout>
+  </BehaviorTree>
+  <TreeNodesModel>
+    <Action ID="Succeed"/>
+  </TreeNodesModel>
+</root>`
+
+	bt, err := LoadXML([]byte(data), testRegistry(t))
+	if err != nil {
+		t.Fatalf("LoadXML() error = %v", err)
+	}
+	sequence := bt.Root.(*Sequence)
+	if sequence.Name != "root_sequence" {
+		t.Errorf("Sequence.Name = %q, want %q", sequence.Name, "root_sequence")
+	}
+	selector, ok := sequence.Children[0].(*Selector)
+	if !ok {
+		t.Fatalf("Fallback loaded as %T, want *Selector", sequence.Children[0])
+	}
+	if retry := selector.Children[1].(*Retry); retry.MaxAttempts != 3 {
+		t.Errorf("Retry.MaxAttempts = %d, want 3", retry.MaxAttempts)
+	}
+	timeout, ok := sequence.Children[1].(*WithTimeout)
+	if !ok || timeout.Duration != 1500*time.Millisecond {
+		t.Fatalf("SubTree loaded as %v, want a WithTimeout of 1.5s", sequence.Children[1])
+	}
+	if _, ok := timeout.Child.(*AlwaysSuccess).Child.(*AsyncAction); !ok {
+		t.Errorf("Action Keep loaded as %T, want *AsyncAction", timeout.Child.(*AlwaysSuccess).Child)
+	}
+	parallel := sequence.Children[2].(*Parallel)
+	if parallel.MinSuccessCount != 1 || parallel.MinFailureCount != 2 {
+		t.Errorf("Parallel counts = %d/%d, want 1/2", parallel.MinSuccessCount, parallel.MinFailureCount)
+	}
+	if _, ok := parallel.Children[0].(*Invert).Child.(*Action); !ok {
+		t.Errorf("shorthand leaf loaded as %T, want *Action", parallel.Children[0].(*Invert).Child)
+	}
+	if repeat := parallel.Children[1].(*RepeatN); repeat.MaxCount != 2 {
+		t.Errorf("RepeatN.MaxCount = %d, want 2", repeat.MaxCount)
+	}
+}
+
+func TestBehaviorTree_MarshalXML_RoundTrip(t *testing.T) {
+	bt := New(&Selector{Children: []Node{
+		&Composite{
+			Conditions: []Node{&Condition{Name: "DoorOpen"}},
+			Child:      &WhileSuccess{Child: &Action{Name: "Succeed"}},
+		},
+		&ReactiveSequence{Name: "keep trying", Children: []Node{
+			&Repeat{Child: &Action{Name: "Fail"}},
+			&Retry{Child: &AsyncAction{Name: "Keep"}},
+		}},
+		&Parallel{Policy: ParallelRace, Children: []Node{&Action{Name: "Succeed"}, &Action{Name: "Fail"}}},
+		&Parallel{MinSuccessCount: 1, MinFailureCount: 2, Children: []Node{&Action{Name: "Succeed"}, &Action{Name: "Fail"}}},
+		&MemorySelector{Children: []Node{&AlwaysFailure{Child: &Action{Name: "Succeed"}}}},
+		&ConcurrentParallel{MinSuccessCount: 1, MaxConcurrency: 2, Children: []Node{&Action{Name: "Succeed"}}},
+		&Log{Message: "done", Child: &WithTimeout{Duration: 3 * time.Second, Child: &Action{Name: "Succeed"}}},
+	}})
+
+	data, err := xml.MarshalIndent(bt, "", "  ")
+	if err != nil {
+		t.Fatalf("xml.Marshal() error = %v", err)
+	}
+	for _, expected := range []string{`<root BTCPP_format="4" main_tree_to_execute="MainTree">`, "<Fallback>", `<Repeat num_cycles="-1">`, "<KeepRunningUntilFailure>", `<Timeout msec="3000">`, `<Action ID="Keep">`} {
+		if !strings.Contains(string(data), expected) {
+			t.Errorf("xml.Marshal() should contain %q, got\n%s", expected, data)
+		}
+	}
+
+	loaded, err := LoadXML(data, testRegistry(t))
+	if err != nil {
+		t.Fatalf("LoadXML() error = %v\n%s", err, data)
+	}
+	want, _ := json.Marshal(bt)
+	got, _ := json.Marshal(loaded)
+	if string(got) != string(want) {
+		t.Errorf("XML round trip changed the tree:\n got %s\nwant %s", got, want)
+	}
+}
+
+func TestLoadXML_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want error
+	}{
+		{name: "unknown node", data: `<root><BehaviorTree ID="A"><Teleport/></BehaviorTree></root>`, want: ErrUnknownNode},
+		{name: "unknown subtree", data: `<root><BehaviorTree ID="A"><SubTree ID="B"/></BehaviorTree></root>`, want: ErrUnknownNode},
+		{name: "recursive subtree", data: `<root><BehaviorTree ID="A"><Sequence><SubTree ID="A"/></Sequence></BehaviorTree></root>`, want: ErrInvalidConfig},
+		{name: "missing msec", data: `<root><BehaviorTree ID="A"><Timeout><Succeed/></Timeout></BehaviorTree></root>`, want: ErrInvalidConfig},
+		{name: "bad num_attempts", data: `<root><BehaviorTree ID="A"><RetryUntilSuccessful num_attempts="x"><Succeed/></RetryUntilSuccessful></BehaviorTree></root>`, want: ErrInvalidConfig},
+		{name: "leaf port", data: `<root><BehaviorTree ID="A"><Succeed target="{door}"/></BehaviorTree></root>`, want: ErrInvalidConfig},
+		{name: "ambiguous main tree", data: `<root><BehaviorTree ID="A"><Succeed/></BehaviorTree><BehaviorTree ID="B"><Succeed/></BehaviorTree></root>`, want: ErrInvalidConfig},
+		{name: "not a root element", data: `<BehaviorTree ID="A"><Succeed/></BehaviorTree>`, want: ErrInvalidConfig},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := LoadXML([]byte(test.data), testRegistry(t)); !errors.Is(err, test.want) {
+				t.Errorf("LoadXML() error = %v, want %v", err, test.want)
+			}
+		})
+	}
+}
+
+func TestBehaviorTree_MarshalXML_Backoff(t *testing.T) {
+	bt := New(&Retry{Child: &Action{Name: "Fail"}, Backoff: FixedBackoff{Interval: time.Second}})
+	if _, err := xml.Marshal(bt); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("xml.Marshal() of a Retry with a Backoff error = %v, want %v", err, ErrInvalidConfig)
+	}
+}
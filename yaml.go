@@ -0,0 +1,152 @@
+package behave
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yamlIncludeTag marks a YAML value that is replaced by the contents of another file.
+const yamlIncludeTag = "!include"
+
+// LoadYAML creates a BehaviorTree from the YAML encoding of the Definition of its root node. The document uses
+// the same keys as the JSON format (type, name, params, conditions and children).
+//
+// Fragments can be reused within a document with YAML anchors and aliases, and a node written as
+// "!include path/to/file.yaml" is replaced by the tree defined in that file, so that a library of behaviors
+// can be kept in separate files. Included files can include other files in turn. LoadYAML resolves include
+// paths relative to the current directory; use LoadYAMLFile to resolve them relative to the including file.
+//
+// Parameters:
+//   - data: The YAML document.
+//   - r: The Registry used to create the nodes. If nil, only the built-in node types are available.
+//
+// Returns:
+//   - A pointer to a new BehaviorTree instance.
+//   - An error if the document or an included file cannot be read, has a key that is not part of the format,
+//     or the tree cannot be built (see Definition.Build).
+func LoadYAML(data []byte, r *Registry) (*BehaviorTree, error) {
+	return loadYAML(data, ".", nil, r)
+}
+
+// LoadYAMLFile creates a BehaviorTree from a YAML file. Include paths are resolved relative to the directory
+// of the file that includes them. See LoadYAML for the format.
+//
+// Parameters:
+//   - name: The path of the YAML file.
+//   - r: The Registry used to create the nodes. If nil, only the built-in node types are available.
+//
+// Returns:
+//   - A pointer to a new BehaviorTree instance.
+//   - An error if a file cannot be read or the tree cannot be loaded (see LoadYAML).
+func LoadYAMLFile(name string, r *Registry) (*BehaviorTree, error) {
+	abs, err := filepath.Abs(name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(abs)
+	if err != nil {
+		return nil, err
+	}
+	return loadYAML(data, filepath.Dir(abs), []string{abs}, r)
+}
+
+// loadYAML creates a BehaviorTree from a YAML document whose includes are resolved relative to dir.
+func loadYAML(data []byte, dir string, including []string, r *Registry) (*BehaviorTree, error) {
+	root, err := parseYAML(data, dir, including)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode the document with all includes in place, rejecting keys that are not part of the format
+	resolved, err := yaml.Marshal(root)
+	if err != nil {
+		return nil, err
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(resolved))
+	decoder.KnownFields(true)
+	var def Definition
+	if err := decoder.Decode(&def); err != nil {
+		return nil, err
+	}
+	node, err := def.BuildWith(r)
+	if err != nil {
+		return nil, err
+	}
+	return New(node), nil
+}
+
+// parseYAML parses a YAML document and replaces its includes with the documents they refer to. The absolute
+// paths of the files being included are passed in including, so that a file including itself is reported
+// rather than expanded forever.
+func parseYAML(data []byte, dir string, including []string) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) != 1 {
+		return nil, fmt.Errorf("%w: YAML document is empty", ErrInvalidConfig)
+	}
+	root := doc.Content[0]
+	if err := resolveIncludes(root, dir, including); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// resolveIncludes replaces every value tagged !include below node with the root of the included document.
+func resolveIncludes(node *yaml.Node, dir string, including []string) error {
+	if node.Kind == yaml.AliasNode {
+		// The anchored node is resolved where it is defined
+		return nil
+	}
+	if node.Kind == yaml.ScalarNode && node.Tag == yamlIncludeTag {
+		path := node.Value
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		path, err := filepath.Abs(path)
+		if err != nil {
+			return err
+		}
+		for _, parent := range including {
+			if parent == path {
+				return fmt.Errorf("%w: %s includes itself", ErrInvalidConfig, path)
+			}
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("including %s: %w", node.Value, err)
+		}
+		included, err := parseYAML(data, filepath.Dir(path), append(including, path))
+		if err != nil {
+			return fmt.Errorf("including %s: %w", node.Value, err)
+		}
+		anchor := node.Anchor
+		*node = *included
+		if anchor != "" {
+			node.Anchor = anchor
+		}
+		return nil
+	}
+	for _, child := range node.Content {
+		if err := resolveIncludes(child, dir, including); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MarshalYAML encodes the BehaviorTree as the YAML encoding of the Definition of its root node, so that
+// yaml.Marshal(bt) writes a document LoadYAML can read. Only the structure and configuration of the tree are
+// encoded, not the status of its nodes or its Blackboard.
+//
+// Returns:
+//   - The Definition of the root node, which the YAML encoder writes.
+//   - An error if the tree cannot be described (see Describe).
+func (bt *BehaviorTree) MarshalYAML() (any, error) {
+	return Describe(bt.Root)
+}
@@ -0,0 +1,167 @@
+package behave
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// writeFiles writes the given files, keyed by their path relative to dir.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestLoadYAML_AnchorsAndAliases(t *testing.T) {
+	data := `
+type: Sequence
+children:
+  - &guard {type: Condition, name: DoorOpen}
+  - type: WithTimeout
+    params: {Duration: 250ms}
+    children:
+      - type: Retry
+        params: {MaxAttempts: 3, Backoff: {Type: Fixed, Interval: 10ms}}
+        children: [{type: Action, name: Succeed}]
+  - *guard
+  - type: Parallel
+    params: &threshold {MinSuccessCount: 1}
+    children: [{type: Action, name: Succeed}]
+  - type: Parallel
+    params:
+      <<: *threshold
+      KeepRunning: true
+    children: [{type: Action, name: Succeed}]
+`
+	bt, err := LoadYAML([]byte(data), testRegistry(t))
+	if err != nil {
+		t.Fatalf("LoadYAML() error = %v", err)
+	}
+	sequence := bt.Root.(*Sequence)
+	if len(sequence.Children) != 5 {
+		t.Fatalf("Sequence has %d children, want 5", len(sequence.Children))
+	}
+	if sequence.Children[0] == sequence.Children[2] {
+		t.Errorf("an aliased fragment should create a new node")
+	}
+	if name := sequence.Children[2].(*Condition).Name; name != "DoorOpen" {
+		t.Errorf("aliased Condition.Name = %q, want %q", name, "DoorOpen")
+	}
+	timeout := sequence.Children[1].(*WithTimeout)
+	if timeout.Duration != 250*time.Millisecond {
+		t.Errorf("WithTimeout.Duration = %v, want %v", timeout.Duration, 250*time.Millisecond)
+	}
+	if retry := timeout.Child.(*Retry); retry.MaxAttempts != 3 || retry.Backoff != (FixedBackoff{Interval: 10 * time.Millisecond}) {
+		t.Errorf("Retry = %d attempts with %v, want 3 attempts with a 10ms fixed backoff", retry.MaxAttempts, retry.Backoff)
+	}
+	if merged := sequence.Children[4].(*Parallel); merged.MinSuccessCount != 1 || !merged.KeepRunning {
+		t.Errorf("merged Parallel = MinSuccessCount %d, KeepRunning %v, want 1, true", merged.MinSuccessCount, merged.KeepRunning)
+	}
+}
+
+func TestLoadYAMLFile_Includes(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"trees/main.yaml": `
+type: Selector
+children:
+  - !include ../library/enter.yaml
+  - !include ../library/enter.yaml
+  - {type: Action, name: Fail}
+`,
+		"library/enter.yaml": `
+type: Sequence
+children:
+  - {type: Condition, name: DoorOpen}
+  - !include common/step.yaml
+`,
+		"library/common/step.yaml": `{type: Action, name: Succeed}`,
+	})
+
+	bt, err := LoadYAMLFile(filepath.Join(dir, "trees", "main.yaml"), testRegistry(t))
+	if err != nil {
+		t.Fatalf("LoadYAMLFile() error = %v", err)
+	}
+	selector := bt.Root.(*Selector)
+	if len(selector.Children) != 3 {
+		t.Fatalf("Selector has %d children, want 3", len(selector.Children))
+	}
+	enter, ok := selector.Children[1].(*Sequence)
+	if !ok || len(enter.Children) != 2 {
+		t.Fatalf("included subtree = %v, want a Sequence with 2 children", selector.Children[1])
+	}
+	if name := enter.Children[1].(*Action).Name; name != "Succeed" {
+		t.Errorf("nested include Action.Name = %q, want %q", name, "Succeed")
+	}
+	if status := bt.Tick(); status != Success {
+		t.Errorf("BehaviorTree.Tick() = %v, want %v", status, Success)
+	}
+}
+
+func TestLoadYAMLFile_Errors(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"loop.yaml":    `{type: Invert, children: [!include loop.yaml]}`,
+		"missing.yaml": `{type: Invert, children: [!include nowhere.yaml]}`,
+		"typo.yaml":    `{type: Invert, childs: [{type: Action, name: Succeed}]}`,
+		"unknown.yaml": `{type: Invert, children: [{type: Action, name: Teleport}]}`,
+	})
+
+	tests := []struct {
+		file  string
+		check func(err error) bool
+	}{
+		{file: "loop.yaml", check: func(err error) bool { return errors.Is(err, ErrInvalidConfig) }},
+		{file: "missing.yaml", check: func(err error) bool { return errors.Is(err, os.ErrNotExist) }},
+		{file: "typo.yaml", check: func(err error) bool { return err != nil && strings.Contains(err.Error(), "childs") }},
+		{file: "unknown.yaml", check: func(err error) bool { return errors.Is(err, ErrUnknownNode) }},
+	}
+	for _, test := range tests {
+		t.Run(test.file, func(t *testing.T) {
+			_, err := LoadYAMLFile(filepath.Join(dir, test.file), testRegistry(t))
+			if !test.check(err) {
+				t.Errorf("LoadYAMLFile() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestBehaviorTree_MarshalYAML_RoundTrip(t *testing.T) {
+	bt := New(&Sequence{Children: []Node{
+		&Composite{Conditions: []Node{&Condition{Name: "DoorOpen"}}, Child: &Action{Name: "Succeed"}},
+		&RepeatN{MaxCount: 2, Child: &AsyncAction{Name: "Keep"}},
+		&Retry{MaxAttempts: 2, Backoff: ExponentialBackoff{Initial: time.Millisecond}, Child: &Action{Name: "Fail"}},
+		&Parallel{MinSuccessCount: 1, Policy: ParallelAny, Children: []Node{&Action{Name: "Succeed"}}},
+	}})
+
+	data, err := yaml.Marshal(bt)
+	if err != nil {
+		t.Fatalf("yaml.Marshal() error = %v", err)
+	}
+	if !strings.HasPrefix(string(data), "type: Sequence\n") {
+		t.Errorf("yaml.Marshal() = %s, want it to start with the root type", data)
+	}
+	loaded, err := LoadYAML(data, testRegistry(t))
+	if err != nil {
+		t.Fatalf("LoadYAML() error = %v\n%s", err, data)
+	}
+	want, _ := json.Marshal(bt)
+	got, _ := json.Marshal(loaded)
+	if string(got) != string(want) {
+		t.Errorf("YAML round trip changed the tree:\n got %s\nwant %s", got, want)
+	}
+}